@@ -0,0 +1,62 @@
+// Command iso8583diff reports the fields added, removed and changed between two JSON or YAML spec files.
+//
+// Usage:
+//
+//	iso8583diff [-json] old.yaml new.yaml
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mercadolibre/go-iso8583/diff"
+	"github.com/mercadolibre/go-iso8583/spec"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "print the differences as JSON")
+	flag.Parse()
+
+	if err := run(flag.Args(), *asJSON); err != nil {
+		fmt.Fprintf(os.Stderr, "iso8583diff: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, asJSON bool) error {
+	if len(args) != 2 {
+		flag.Usage()
+		return fmt.Errorf("the old and the new spec files are required")
+	}
+
+	old, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	new, err := spec.Load(args[1])
+	if err != nil {
+		return err
+	}
+
+	diffs, err := diff.Compare(old, new)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		if diffs == nil {
+			diffs = []diff.Difference{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diffs)
+	}
+
+	for _, d := range diffs {
+		fmt.Println(d)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Command iso8583doc generates the field reference of a JSON or YAML spec file as a Markdown or HTML table.
+//
+// Usage:
+//
+//	iso8583doc -spec visa.yaml -format html -title "Visa BASE I" -o visa.html
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mercadolibre/go-iso8583/docgen"
+	"github.com/mercadolibre/go-iso8583/spec"
+)
+
+func main() {
+	specFile := flag.String("spec", "", "spec definition file")
+	format := flag.String("format", string(docgen.Markdown), "format of the document, markdown or html")
+	title := flag.String("title", "", "title of the document")
+	output := flag.String("o", "", "output file, by default the standard output")
+	flag.Parse()
+
+	if err := run(*specFile, docgen.Format(*format), *title, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "iso8583doc: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specFile string, format docgen.Format, title, output string) error {
+	if specFile == "" {
+		flag.Usage()
+		return fmt.Errorf("-spec is required")
+	}
+
+	definition, err := spec.Load(specFile)
+	if err != nil {
+		return err
+	}
+
+	doc, err := docgen.Generate(definition, docgen.Options{Format: format, Title: title})
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(doc)
+		return err
+	}
+
+	return os.WriteFile(output, doc, 0o644)
+}
@@ -0,0 +1,52 @@
+// Command iso8583gen generates typed Go structs with pack/unpack methods from a JSON or YAML spec file.
+//
+// Usage:
+//
+//	iso8583gen -spec visa.json -package visa -type Authorization -o authorization_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mercadolibre/go-iso8583/codegen"
+	"github.com/mercadolibre/go-iso8583/spec"
+)
+
+func main() {
+	specFile := flag.String("spec", "", "spec definition file")
+	pkg := flag.String("package", "", "package name of the generated file")
+	typeName := flag.String("type", "Message", "name of the generated root struct")
+	output := flag.String("o", "", "output file, by default the standard output")
+	flag.Parse()
+
+	if err := run(*specFile, *pkg, *typeName, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "iso8583gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specFile, pkg, typeName, output string) error {
+	if specFile == "" || pkg == "" {
+		flag.Usage()
+		return fmt.Errorf("-spec and -package are required")
+	}
+
+	definition, err := spec.Load(specFile)
+	if err != nil {
+		return err
+	}
+
+	src, err := codegen.Generate(definition, codegen.Options{Package: pkg, TypeName: typeName})
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+
+	return os.WriteFile(output, src, 0o644)
+}
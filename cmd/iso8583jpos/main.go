@@ -0,0 +1,45 @@
+// Command iso8583jpos imports a jPOS GenericPackager XML file into a JSON or YAML spec file, the fields whose class
+// cannot be translated are listed in the standard error.
+//
+// Usage:
+//
+//	iso8583jpos -in iso87binary.xml -o iso87binary.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mercadolibre/go-iso8583/jpos"
+	"github.com/mercadolibre/go-iso8583/spec"
+)
+
+func main() {
+	input := flag.String("in", "", "jPOS GenericPackager XML file")
+	output := flag.String("o", "", "output spec file, .yaml or .yml for YAML, JSON otherwise")
+	flag.Parse()
+
+	if err := run(*input, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "iso8583jpos: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(input, output string) error {
+	if input == "" || output == "" {
+		flag.Usage()
+		return fmt.Errorf("-in and -o are required")
+	}
+
+	definition, report, err := jpos.ImportFile(input)
+	if err != nil {
+		return err
+	}
+
+	for _, unsupported := range report.Unsupported {
+		fmt.Fprintf(os.Stderr, "unsupported field %s (%s): %s\n", unsupported.Path, unsupported.Class, unsupported.Reason)
+	}
+
+	return spec.Save(output, definition)
+}
@@ -0,0 +1,65 @@
+// Command iso8583lint reports the misconfigured fields of JSON or YAML spec files.
+//
+// Usage:
+//
+//	iso8583lint [-json] spec.yaml...
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mercadolibre/go-iso8583/lint"
+	"github.com/mercadolibre/go-iso8583/spec"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "print the issues as JSON")
+	flag.Parse()
+
+	found, err := run(flag.Args(), *asJSON)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "iso8583lint: %v\n", err)
+		os.Exit(2)
+	}
+
+	if found {
+		os.Exit(1)
+	}
+}
+
+func run(args []string, asJSON bool) (bool, error) {
+	if len(args) == 0 {
+		flag.Usage()
+		return false, fmt.Errorf("at least one spec file is required")
+	}
+
+	found := false
+	for _, filename := range args {
+		s, err := spec.Load(filename)
+		if err != nil {
+			return false, err
+		}
+
+		issues := lint.Check(s)
+		found = found || len(issues) > 0
+		if asJSON {
+			if issues == nil {
+				issues = []lint.Issue{}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(map[string]interface{}{"file": filename, "issues": issues}); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		for _, issue := range issues {
+			fmt.Printf("%s: %s\n", filename, issue)
+		}
+	}
+	return found, nil
+}
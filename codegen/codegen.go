@@ -0,0 +1,516 @@
+// Package codegen generates typed Go structs, field constants and pack/unpack methods from a spec.
+//
+// Every composite serdes (BitMapped, List, TLV and BerTLV) is generated as a struct, VarLength is transparent and
+// the anonymous items of a List are inlined in the parent struct. Leaf fields are generated as strings, empty
+// strings are not packed, and composite fields as pointers, nil pointers are not packed.
+//
+// The numeric leaves (Bcd, AsciiNumeric and EbcdicNumeric) are generated as a named string type of the root, like
+// AuthorizationNumeric, which keeps the leading zeros and the length of the digits. Its constructor and Pack reject
+// the values that are not digits, and Unpack converts the decoded strings with the constructor.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+const defaultTypeName = "Message"
+
+type Options struct {
+	// Package is the package name of the generated file.
+	Package string
+	// TypeName is the name of the root struct, by default Message.
+	TypeName string
+}
+
+type Error struct {
+	Message string `json:"message"`
+	Path    string `json:"path"`
+}
+
+func (err Error) Error() string {
+	if err.Path != "" {
+		return fmt.Sprintf("%s: path: %s.", err.Message, err.Path)
+	}
+	return err.Message
+}
+
+type structDef struct {
+	name   string
+	desc   string
+	fields []fieldDef
+}
+
+type fieldDef struct {
+	goName     string
+	key        string
+	desc       string
+	structName string // empty for leaf fields
+	numeric    bool
+}
+
+type generator struct {
+	opts     Options
+	structs  []*structDef
+	names    map[string]bool
+	numerics bool // some leaf is numeric
+}
+
+// Generate returns the formatted Go source code for the spec.
+func Generate(spec serdes.Serdes, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, Error{Message: "package name is required"}
+	}
+
+	if opts.TypeName == "" {
+		opts.TypeName = defaultTypeName
+	}
+
+	gen := &generator{opts: opts, names: map[string]bool{}}
+	if _, err := gen.collectStruct("", opts.TypeName, spec); err != nil {
+		return nil, err
+	}
+
+	literal, usesBinary, err := specLiteral(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	src := gen.source(literal, usesBinary)
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w", err)
+	}
+
+	return formatted, nil
+}
+
+func (gen *generator) collectStruct(path string, name string, s serdes.Serdes) (*structDef, error) {
+	def := &structDef{name: gen.uniqueTypeName(name), desc: descOf(s)}
+	gen.structs = append(gen.structs, def)
+
+	if err := gen.collectFields(path, def, s); err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+func (gen *generator) collectFields(path string, def *structDef, s serdes.Serdes) error {
+	items, ok := compositeItems(unwrap(s))
+	if !ok {
+		return Error{Message: fmt.Sprintf("serdes %s is not a composite type", s.Name()), Path: path}
+	}
+
+	goNames := map[string]bool{}
+	for _, field := range def.fields {
+		goNames[field.goName] = true
+	}
+
+	for _, item := range items {
+		if item.SerDes == nil {
+			return Error{Message: "field without serdes", Path: serdes.JoinPath(path, item.Name)}
+		}
+
+		child := unwrap(item.SerDes)
+		if item.Name == "" {
+			// anonymous items are inlined in the parent, like types.List does with their values.
+			if err := gen.collectFields(path, def, child); err != nil {
+				return err
+			}
+
+			for _, field := range def.fields {
+				goNames[field.goName] = true
+			}
+			continue
+		}
+
+		itemPath := serdes.JoinPath(path, item.Name)
+		field := fieldDef{key: item.Name, desc: descOf(item.SerDes)}
+		field.goName = uniqueName(goNames, goName(field.desc, item.Name), item.Name)
+
+		if _, composite := compositeItems(child); composite {
+			childDef, err := gen.collectStruct(itemPath, def.name+field.goName, item.SerDes)
+			if err != nil {
+				return err
+			}
+			field.structName = childDef.name
+		} else if !isLeaf(child) {
+			return Error{Message: fmt.Sprintf("unsupported serdes %s", child.Name()), Path: itemPath}
+		} else if isNumeric(child) {
+			field.numeric = true
+			gen.numerics = true
+		}
+
+		def.fields = append(def.fields, field)
+	}
+
+	return nil
+}
+
+func (gen *generator) uniqueTypeName(name string) string {
+	unique := name
+	for count := 2; gen.names[unique]; count++ {
+		unique = fmt.Sprintf("%s%d", name, count)
+	}
+	gen.names[unique] = true
+	return unique
+}
+
+func (gen *generator) source(literal string, usesBinary bool) []byte {
+	root := gen.structs[0]
+	helper := lowerFirst(root.name)
+
+	src := new(bytes.Buffer)
+	fmt.Fprintf(src, "// Code generated by iso8583gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(src, "package %s\n\n", gen.opts.Package)
+	fmt.Fprintf(src, "import (\n\"bytes\"\n")
+	if usesBinary {
+		fmt.Fprintf(src, "\"encoding/binary\"\n")
+	}
+	fmt.Fprintf(src, "\"fmt\"\n\n\"github.com/mercadolibre/go-iso8583/serdes\"\n\"github.com/mercadolibre/go-iso8583/types\"\n)\n\n")
+
+	fmt.Fprintf(src, "// %sSpec is the spec used to pack and unpack %s.\n", root.name, root.name)
+	fmt.Fprintf(src, "var %sSpec serdes.Serdes = %s\n\n", root.name, literal)
+
+	for _, def := range gen.structs {
+		writeConstants(src, def)
+	}
+
+	if gen.numerics {
+		writeNumeric(src, root.name, helper)
+	}
+
+	for _, def := range gen.structs {
+		writeStruct(src, def, root.name)
+		writeToMap(src, def)
+		writeFromMap(src, def, helper)
+		if gen.numerics {
+			writeValidate(src, def, root.name)
+		}
+	}
+
+	validate := ""
+	if gen.numerics {
+		validate = "if err := m.Validate(); err != nil {\nreturn nil, err\n}\n\n"
+	}
+
+	fmt.Fprintf(src, `// Pack serializes the message with %[1]sSpec.
+func (m *%[1]s) Pack() ([]byte, error) {
+	%[3]sdata, err := %[1]sSpec.Serialize(m.ToMap())
+	if err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+// Unpack deserializes the data with %[1]sSpec into the message.
+func (m *%[1]s) Unpack(data []byte) error {
+	value, err := %[1]sSpec.Deserialize(bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+
+	mapValue, ok := value.(serdes.Map)
+	if !ok {
+		return fmt.Errorf("invalid value [%%T], expected: %%T", value, serdes.Map{})
+	}
+
+	*m = %[1]s{}
+	return m.FromMap(mapValue)
+}
+
+func %[2]sString(values serdes.Map, key string, dst *string) error {
+	value, exists := values[key]
+	if !exists || value == nil {
+		return nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("field %%s: invalid value [%%T], expected: string", key, value)
+	}
+
+	*dst = str
+	return nil
+}
+
+func %[2]sMap(values serdes.Map, key string) (serdes.Map, bool, error) {
+	value, exists := values[key]
+	if !exists || value == nil {
+		return nil, false, nil
+	}
+
+	mapValue, ok := value.(serdes.Map)
+	if !ok {
+		return nil, false, fmt.Errorf("field %%s: invalid value [%%T], expected: %%T", key, value, serdes.Map{})
+	}
+
+	return mapValue, true, nil
+}
+`, root.name, helper, validate)
+
+	return src.Bytes()
+}
+
+func writeConstants(src *bytes.Buffer, def *structDef) {
+	if len(def.fields) == 0 {
+		return
+	}
+
+	fmt.Fprintf(src, "// Keys of the %s fields.\nconst (\n", def.name)
+	for _, field := range def.fields {
+		fmt.Fprintf(src, "%sField%s = %q\n", def.name, field.goName, field.key)
+	}
+	fmt.Fprintf(src, ")\n\n")
+}
+
+// writeNumeric writes the type of the numeric fields, its constructor and the helper that converts the decoded values.
+func writeNumeric(src *bytes.Buffer, root string, helper string) {
+	fmt.Fprintf(src, `// %[1]sNumeric is the value of the numeric fields of %[1]s, the digits keep their leading zeros. The D separator
+// of the BCD track data is accepted too.
+type %[1]sNumeric string
+
+// New%[1]sNumeric returns the digits as a numeric value, it fails when they are not digits.
+func New%[1]sNumeric(digits string) (%[1]sNumeric, error) {
+	for _, c := range digits {
+		if (c < '0' || c > '9') && c != 'D' && c != 'd' {
+			return "", fmt.Errorf("invalid numeric character %%q", c)
+		}
+	}
+	return %[1]sNumeric(digits), nil
+}
+
+func %[2]sNumeric(values serdes.Map, key string, dst *%[1]sNumeric) error {
+	var digits string
+	if err := %[2]sString(values, key, &digits); err != nil {
+		return err
+	}
+
+	numeric, err := New%[1]sNumeric(digits)
+	if err != nil {
+		return fmt.Errorf("field %%s: %%w", key, err)
+	}
+
+	*dst = numeric
+	return nil
+}
+
+`, root, helper)
+}
+
+func writeStruct(src *bytes.Buffer, def *structDef, root string) {
+	if def.desc != "" {
+		fmt.Fprintf(src, "// %s - %s.\n", def.name, sanitizeComment(def.desc))
+	}
+
+	fmt.Fprintf(src, "type %s struct {\n", def.name)
+	for _, field := range def.fields {
+		if field.desc != "" {
+			fmt.Fprintf(src, "// %s - %s.\n", field.key, sanitizeComment(field.desc))
+		}
+
+		fieldType := "string"
+		if field.structName != "" {
+			fieldType = "*" + field.structName
+		} else if field.numeric {
+			fieldType = root + "Numeric"
+		}
+		fmt.Fprintf(src, "%s %s `iso8583:%q`\n", field.goName, fieldType, field.key)
+	}
+	fmt.Fprintf(src, "}\n\n")
+}
+
+func writeToMap(src *bytes.Buffer, def *structDef) {
+	fmt.Fprintf(src, "// ToMap returns the values of %s as expected by its serdes.\n", def.name)
+	fmt.Fprintf(src, "func (m *%s) ToMap() serdes.Map {\nvalues := serdes.Map{}\n", def.name)
+	for _, field := range def.fields {
+		constant := def.name + "Field" + field.goName
+		if field.numeric {
+			fmt.Fprintf(src, "if m.%s != \"\" {\nvalues[%s] = string(m.%s)\n}\n", field.goName, constant, field.goName)
+			continue
+		}
+
+		if field.structName == "" {
+			fmt.Fprintf(src, "if m.%s != \"\" {\nvalues[%s] = m.%s\n}\n", field.goName, constant, field.goName)
+			continue
+		}
+		fmt.Fprintf(src, "if m.%s != nil {\nvalues[%s] = m.%s.ToMap()\n}\n", field.goName, constant, field.goName)
+	}
+	fmt.Fprintf(src, "return values\n}\n\n")
+}
+
+func writeFromMap(src *bytes.Buffer, def *structDef, helper string) {
+	fmt.Fprintf(src, "// FromMap sets the fields of %s from the values decoded by its serdes.\n", def.name)
+	fmt.Fprintf(src, "func (m *%s) FromMap(values serdes.Map) error {\n", def.name)
+	for _, field := range def.fields {
+		constant := def.name + "Field" + field.goName
+		if field.structName == "" {
+			kind := "String"
+			if field.numeric {
+				kind = "Numeric"
+			}
+			fmt.Fprintf(src, "if err := %s%s(values, %s, &m.%s); err != nil {\nreturn err\n}\n",
+				helper, kind, constant, field.goName)
+			continue
+		}
+
+		fmt.Fprintf(src, `if mapValue, ok, err := %[1]sMap(values, %[2]s); err != nil {
+	return err
+} else if ok {
+	m.%[3]s = new(%[4]s)
+	if err := m.%[3]s.FromMap(mapValue); err != nil {
+		return fmt.Errorf("field %%s: %%w", %[2]s, err)
+	}
+}
+`, helper, constant, field.goName, field.structName)
+	}
+	fmt.Fprintf(src, "return nil\n}\n\n")
+}
+
+func writeValidate(src *bytes.Buffer, def *structDef, root string) {
+	fmt.Fprintf(src, "// Validate checks the numeric fields of %s and of its composite fields.\n", def.name)
+	fmt.Fprintf(src, "func (m *%s) Validate() error {\n", def.name)
+	for _, field := range def.fields {
+		constant := def.name + "Field" + field.goName
+		if field.numeric {
+			fmt.Fprintf(src, `if _, err := New%[1]sNumeric(string(m.%[2]s)); err != nil {
+	return fmt.Errorf("field %%s: %%w", %[3]s, err)
+}
+`, root, field.goName, constant)
+			continue
+		}
+
+		if field.structName != "" {
+			fmt.Fprintf(src, `if m.%[1]s != nil {
+	if err := m.%[1]s.Validate(); err != nil {
+		return fmt.Errorf("field %%s: %%w", %[2]s, err)
+	}
+}
+`, field.goName, constant)
+		}
+	}
+	fmt.Fprintf(src, "return nil\n}\n\n")
+}
+
+// unwrap returns the serdes that defines the value of var length types.
+func unwrap(s serdes.Serdes) serdes.Serdes {
+	for {
+		varLen, ok := s.(types.VarLength)
+		if !ok {
+			return s
+		}
+		s = varLen.Data
+	}
+}
+
+func compositeItems(s serdes.Serdes) ([]types.Field, bool) {
+	switch value := s.(type) {
+	case types.List:
+		return value.Items, true
+	case types.TLV:
+		return value.Items, true
+	case types.BerTLV:
+		return value.Items, true
+	case types.BitMapped:
+		bits := make([]int, 0, len(value.Mapping))
+		for bit := range value.Mapping {
+			bits = append(bits, bit)
+		}
+		sort.Ints(bits)
+
+		items := make([]types.Field, 0, len(bits))
+		for _, bit := range bits {
+			items = append(items, types.Field{Name: fmt.Sprint(bit), SerDes: value.Mapping[bit]})
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+func isLeaf(s serdes.Serdes) bool {
+	switch s.(type) {
+	case types.Bcd, types.Ebcdic, types.EbcdicNumeric, types.Ascii, types.AsciiNumeric, types.Raw, types.Byte, types.Word:
+		return true
+	}
+	return false
+}
+
+// isNumeric returns true for the leaves whose values are digits.
+func isNumeric(s serdes.Serdes) bool {
+	switch s.(type) {
+	case types.Bcd, types.AsciiNumeric, types.EbcdicNumeric:
+		return true
+	}
+	return false
+}
+
+func descOf(s serdes.Serdes) string {
+	if described, ok := s.(types.Described); ok {
+		return described.Description()
+	}
+	return ""
+}
+
+// goName builds an exported identifier from the description, or from the key when there is no description.
+func goName(desc string, key string) string {
+	name := camelCase(desc)
+	if name == "" {
+		name = camelCase(key)
+	}
+
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "Field" + name
+	}
+	return name
+}
+
+func uniqueName(used map[string]bool, name string, key string) string {
+	unique := name
+	if used[unique] {
+		unique = name + camelCase(key)
+	}
+
+	for count := 2; used[unique]; count++ {
+		unique = fmt.Sprintf("%s%d", name, count)
+	}
+
+	used[unique] = true
+	return unique
+}
+
+func camelCase(str string) string {
+	var out strings.Builder
+	upper := true
+	for _, r := range str {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+func lowerFirst(str string) string {
+	if str == "" {
+		return str
+	}
+	return strings.ToLower(str[:1]) + str[1:]
+}
+
+func sanitizeComment(str string) string {
+	return strings.TrimSuffix(strings.Join(strings.Fields(str), " "), ".")
+}
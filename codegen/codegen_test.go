@@ -0,0 +1,54 @@
+package codegen_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/codegen"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/spec"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Generate_Matches_Example(t *testing.T) {
+	definition, err := spec.Load("testdata/message.json")
+	assert.NoError(t, err)
+
+	src, err := codegen.Generate(definition, codegen.Options{Package: "example", TypeName: "Authorization"})
+	assert.NoError(t, err)
+
+	expected, err := os.ReadFile("example/authorization_gen.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(src), "run go generate ./codegen/example to update the example")
+}
+
+func Test_Generate_Errors(t *testing.T) {
+	_, err := codegen.Generate(types.List{}, codegen.Options{})
+	assert.EqualError(t, err, "package name is required")
+
+	_, err = codegen.Generate(types.Bcd{}, codegen.Options{Package: "example"})
+	assert.EqualError(t, err, "serdes bcd is not a composite type")
+
+	custom := types.List{Items: []types.Field{{Name: "1", SerDes: &serdes.Mock{}}}}
+	_, err = codegen.Generate(custom, codegen.Options{Package: "example"})
+	assert.EqualError(t, err, "unsupported serdes mock: path: 1.")
+}
+
+func Test_Generate_Unique_Names(t *testing.T) {
+	definition := types.BitMapped{
+		Bitmap: types.Bitmap{BlockSize: 64, NumBits: 64},
+		Mapping: map[int]serdes.Serdes{
+			2: types.Ebcdic{Desc: "Reserved"},
+			3: types.Ebcdic{Desc: "Reserved"},
+			4: types.Ebcdic{Desc: "Reserved"},
+		},
+	}
+
+	src, err := codegen.Generate(definition, codegen.Options{Package: "example"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(src), "MessageFieldReserved  = \"2\"")
+	assert.Contains(t, string(src), "MessageFieldReserved3 = \"3\"")
+	assert.Contains(t, string(src), "MessageFieldReserved4 = \"4\"")
+}
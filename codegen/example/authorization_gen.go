@@ -0,0 +1,471 @@
+// Code generated by iso8583gen. DO NOT EDIT.
+
+package example
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// AuthorizationSpec is the spec used to pack and unpack Authorization.
+var AuthorizationSpec serdes.Serdes = types.List{
+	Desc: "Authorization message",
+	Items: []types.Field{
+		{Name: "mti", SerDes: types.Bcd{Desc: "Message type identifier", NumDigits: 4, NotPadded: true}},
+		{Name: "", SerDes: types.BitMapped{
+			Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128},
+			Mapping: map[int]serdes.Serdes{
+				2:  types.VarLength{Desc: "Primary account number", Length: types.Byte{}, Data: types.Bcd{Mask: types.MaskPAN}},
+				3:  types.Bcd{Desc: "Processing code", NumDigits: 6, NotPadded: true},
+				4:  types.Bcd{Desc: "Amount, transaction", NumDigits: 12},
+				11: types.Bcd{Desc: "System trace audit number", NumDigits: 6, NotPadded: true},
+				41: types.Ebcdic{Desc: "Card acceptor terminal identification", NumDigits: 8},
+				48: types.VarLength{Desc: "Additional data", Length: types.EbcdicNumeric{NumDigits: 3}, Data: types.List{
+					Items: []types.Field{
+						{Name: "tcc", SerDes: types.Ebcdic{Desc: "Transaction category code", NumDigits: 1}},
+						{Name: "se", SerDes: types.TLV{
+							Desc: "Subelements",
+							Items: []types.Field{
+								{Name: "21", SerDes: types.Ebcdic{}},
+								{Name: "61", SerDes: types.Ebcdic{}},
+							},
+						}},
+					},
+				}},
+				55: types.VarLength{Desc: "ICC data", Length: types.Byte{}, Data: types.BerTLV{
+					Items: []types.Field{
+						{Name: "9f26", SerDes: types.Raw{Desc: "Application cryptogram"}},
+						{Name: "9f36", SerDes: types.Raw{Desc: "Application transaction counter"}},
+					},
+				}},
+				62: types.VarLength{Desc: "Custom payment service fields", Length: types.Word{Order: binary.BigEndian}, Data: types.BitMapped{
+					Bitmap: types.Bitmap{BlockSize: 64, NumBits: 64},
+					Mapping: map[int]serdes.Serdes{
+						1: types.Ebcdic{Desc: "Authorization characteristics indicator", NumDigits: 1},
+						2: types.Bcd{Desc: "Transaction identifier", NumDigits: 15, NotPadded: true},
+					},
+				}},
+			},
+		}},
+	},
+}
+
+// Keys of the Authorization fields.
+const (
+	AuthorizationFieldMessageTypeIdentifier              = "mti"
+	AuthorizationFieldPrimaryAccountNumber               = "2"
+	AuthorizationFieldProcessingCode                     = "3"
+	AuthorizationFieldAmountTransaction                  = "4"
+	AuthorizationFieldSystemTraceAuditNumber             = "11"
+	AuthorizationFieldCardAcceptorTerminalIdentification = "41"
+	AuthorizationFieldAdditionalData                     = "48"
+	AuthorizationFieldICCData                            = "55"
+	AuthorizationFieldCustomPaymentServiceFields         = "62"
+)
+
+// Keys of the AuthorizationAdditionalData fields.
+const (
+	AuthorizationAdditionalDataFieldTransactionCategoryCode = "tcc"
+	AuthorizationAdditionalDataFieldSubelements             = "se"
+)
+
+// Keys of the AuthorizationAdditionalDataSubelements fields.
+const (
+	AuthorizationAdditionalDataSubelementsFieldField21 = "21"
+	AuthorizationAdditionalDataSubelementsFieldField61 = "61"
+)
+
+// Keys of the AuthorizationICCData fields.
+const (
+	AuthorizationICCDataFieldApplicationCryptogram         = "9f26"
+	AuthorizationICCDataFieldApplicationTransactionCounter = "9f36"
+)
+
+// Keys of the AuthorizationCustomPaymentServiceFields fields.
+const (
+	AuthorizationCustomPaymentServiceFieldsFieldAuthorizationCharacteristicsIndicator = "1"
+	AuthorizationCustomPaymentServiceFieldsFieldTransactionIdentifier                 = "2"
+)
+
+// AuthorizationNumeric is the value of the numeric fields of Authorization, the digits keep their leading zeros. The D separator
+// of the BCD track data is accepted too.
+type AuthorizationNumeric string
+
+// NewAuthorizationNumeric returns the digits as a numeric value, it fails when they are not digits.
+func NewAuthorizationNumeric(digits string) (AuthorizationNumeric, error) {
+	for _, c := range digits {
+		if (c < '0' || c > '9') && c != 'D' && c != 'd' {
+			return "", fmt.Errorf("invalid numeric character %q", c)
+		}
+	}
+	return AuthorizationNumeric(digits), nil
+}
+
+func authorizationNumeric(values serdes.Map, key string, dst *AuthorizationNumeric) error {
+	var digits string
+	if err := authorizationString(values, key, &digits); err != nil {
+		return err
+	}
+
+	numeric, err := NewAuthorizationNumeric(digits)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", key, err)
+	}
+
+	*dst = numeric
+	return nil
+}
+
+// Authorization - Authorization message.
+type Authorization struct {
+	// mti - Message type identifier.
+	MessageTypeIdentifier AuthorizationNumeric `iso8583:"mti"`
+	// 2 - Primary account number.
+	PrimaryAccountNumber AuthorizationNumeric `iso8583:"2"`
+	// 3 - Processing code.
+	ProcessingCode AuthorizationNumeric `iso8583:"3"`
+	// 4 - Amount, transaction.
+	AmountTransaction AuthorizationNumeric `iso8583:"4"`
+	// 11 - System trace audit number.
+	SystemTraceAuditNumber AuthorizationNumeric `iso8583:"11"`
+	// 41 - Card acceptor terminal identification.
+	CardAcceptorTerminalIdentification string `iso8583:"41"`
+	// 48 - Additional data.
+	AdditionalData *AuthorizationAdditionalData `iso8583:"48"`
+	// 55 - ICC data.
+	ICCData *AuthorizationICCData `iso8583:"55"`
+	// 62 - Custom payment service fields.
+	CustomPaymentServiceFields *AuthorizationCustomPaymentServiceFields `iso8583:"62"`
+}
+
+// ToMap returns the values of Authorization as expected by its serdes.
+func (m *Authorization) ToMap() serdes.Map {
+	values := serdes.Map{}
+	if m.MessageTypeIdentifier != "" {
+		values[AuthorizationFieldMessageTypeIdentifier] = string(m.MessageTypeIdentifier)
+	}
+	if m.PrimaryAccountNumber != "" {
+		values[AuthorizationFieldPrimaryAccountNumber] = string(m.PrimaryAccountNumber)
+	}
+	if m.ProcessingCode != "" {
+		values[AuthorizationFieldProcessingCode] = string(m.ProcessingCode)
+	}
+	if m.AmountTransaction != "" {
+		values[AuthorizationFieldAmountTransaction] = string(m.AmountTransaction)
+	}
+	if m.SystemTraceAuditNumber != "" {
+		values[AuthorizationFieldSystemTraceAuditNumber] = string(m.SystemTraceAuditNumber)
+	}
+	if m.CardAcceptorTerminalIdentification != "" {
+		values[AuthorizationFieldCardAcceptorTerminalIdentification] = m.CardAcceptorTerminalIdentification
+	}
+	if m.AdditionalData != nil {
+		values[AuthorizationFieldAdditionalData] = m.AdditionalData.ToMap()
+	}
+	if m.ICCData != nil {
+		values[AuthorizationFieldICCData] = m.ICCData.ToMap()
+	}
+	if m.CustomPaymentServiceFields != nil {
+		values[AuthorizationFieldCustomPaymentServiceFields] = m.CustomPaymentServiceFields.ToMap()
+	}
+	return values
+}
+
+// FromMap sets the fields of Authorization from the values decoded by its serdes.
+func (m *Authorization) FromMap(values serdes.Map) error {
+	if err := authorizationNumeric(values, AuthorizationFieldMessageTypeIdentifier, &m.MessageTypeIdentifier); err != nil {
+		return err
+	}
+	if err := authorizationNumeric(values, AuthorizationFieldPrimaryAccountNumber, &m.PrimaryAccountNumber); err != nil {
+		return err
+	}
+	if err := authorizationNumeric(values, AuthorizationFieldProcessingCode, &m.ProcessingCode); err != nil {
+		return err
+	}
+	if err := authorizationNumeric(values, AuthorizationFieldAmountTransaction, &m.AmountTransaction); err != nil {
+		return err
+	}
+	if err := authorizationNumeric(values, AuthorizationFieldSystemTraceAuditNumber, &m.SystemTraceAuditNumber); err != nil {
+		return err
+	}
+	if err := authorizationString(values, AuthorizationFieldCardAcceptorTerminalIdentification, &m.CardAcceptorTerminalIdentification); err != nil {
+		return err
+	}
+	if mapValue, ok, err := authorizationMap(values, AuthorizationFieldAdditionalData); err != nil {
+		return err
+	} else if ok {
+		m.AdditionalData = new(AuthorizationAdditionalData)
+		if err := m.AdditionalData.FromMap(mapValue); err != nil {
+			return fmt.Errorf("field %s: %w", AuthorizationFieldAdditionalData, err)
+		}
+	}
+	if mapValue, ok, err := authorizationMap(values, AuthorizationFieldICCData); err != nil {
+		return err
+	} else if ok {
+		m.ICCData = new(AuthorizationICCData)
+		if err := m.ICCData.FromMap(mapValue); err != nil {
+			return fmt.Errorf("field %s: %w", AuthorizationFieldICCData, err)
+		}
+	}
+	if mapValue, ok, err := authorizationMap(values, AuthorizationFieldCustomPaymentServiceFields); err != nil {
+		return err
+	} else if ok {
+		m.CustomPaymentServiceFields = new(AuthorizationCustomPaymentServiceFields)
+		if err := m.CustomPaymentServiceFields.FromMap(mapValue); err != nil {
+			return fmt.Errorf("field %s: %w", AuthorizationFieldCustomPaymentServiceFields, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the numeric fields of Authorization and of its composite fields.
+func (m *Authorization) Validate() error {
+	if _, err := NewAuthorizationNumeric(string(m.MessageTypeIdentifier)); err != nil {
+		return fmt.Errorf("field %s: %w", AuthorizationFieldMessageTypeIdentifier, err)
+	}
+	if _, err := NewAuthorizationNumeric(string(m.PrimaryAccountNumber)); err != nil {
+		return fmt.Errorf("field %s: %w", AuthorizationFieldPrimaryAccountNumber, err)
+	}
+	if _, err := NewAuthorizationNumeric(string(m.ProcessingCode)); err != nil {
+		return fmt.Errorf("field %s: %w", AuthorizationFieldProcessingCode, err)
+	}
+	if _, err := NewAuthorizationNumeric(string(m.AmountTransaction)); err != nil {
+		return fmt.Errorf("field %s: %w", AuthorizationFieldAmountTransaction, err)
+	}
+	if _, err := NewAuthorizationNumeric(string(m.SystemTraceAuditNumber)); err != nil {
+		return fmt.Errorf("field %s: %w", AuthorizationFieldSystemTraceAuditNumber, err)
+	}
+	if m.AdditionalData != nil {
+		if err := m.AdditionalData.Validate(); err != nil {
+			return fmt.Errorf("field %s: %w", AuthorizationFieldAdditionalData, err)
+		}
+	}
+	if m.ICCData != nil {
+		if err := m.ICCData.Validate(); err != nil {
+			return fmt.Errorf("field %s: %w", AuthorizationFieldICCData, err)
+		}
+	}
+	if m.CustomPaymentServiceFields != nil {
+		if err := m.CustomPaymentServiceFields.Validate(); err != nil {
+			return fmt.Errorf("field %s: %w", AuthorizationFieldCustomPaymentServiceFields, err)
+		}
+	}
+	return nil
+}
+
+// AuthorizationAdditionalData - Additional data.
+type AuthorizationAdditionalData struct {
+	// tcc - Transaction category code.
+	TransactionCategoryCode string `iso8583:"tcc"`
+	// se - Subelements.
+	Subelements *AuthorizationAdditionalDataSubelements `iso8583:"se"`
+}
+
+// ToMap returns the values of AuthorizationAdditionalData as expected by its serdes.
+func (m *AuthorizationAdditionalData) ToMap() serdes.Map {
+	values := serdes.Map{}
+	if m.TransactionCategoryCode != "" {
+		values[AuthorizationAdditionalDataFieldTransactionCategoryCode] = m.TransactionCategoryCode
+	}
+	if m.Subelements != nil {
+		values[AuthorizationAdditionalDataFieldSubelements] = m.Subelements.ToMap()
+	}
+	return values
+}
+
+// FromMap sets the fields of AuthorizationAdditionalData from the values decoded by its serdes.
+func (m *AuthorizationAdditionalData) FromMap(values serdes.Map) error {
+	if err := authorizationString(values, AuthorizationAdditionalDataFieldTransactionCategoryCode, &m.TransactionCategoryCode); err != nil {
+		return err
+	}
+	if mapValue, ok, err := authorizationMap(values, AuthorizationAdditionalDataFieldSubelements); err != nil {
+		return err
+	} else if ok {
+		m.Subelements = new(AuthorizationAdditionalDataSubelements)
+		if err := m.Subelements.FromMap(mapValue); err != nil {
+			return fmt.Errorf("field %s: %w", AuthorizationAdditionalDataFieldSubelements, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the numeric fields of AuthorizationAdditionalData and of its composite fields.
+func (m *AuthorizationAdditionalData) Validate() error {
+	if m.Subelements != nil {
+		if err := m.Subelements.Validate(); err != nil {
+			return fmt.Errorf("field %s: %w", AuthorizationAdditionalDataFieldSubelements, err)
+		}
+	}
+	return nil
+}
+
+// AuthorizationAdditionalDataSubelements - Subelements.
+type AuthorizationAdditionalDataSubelements struct {
+	Field21 string `iso8583:"21"`
+	Field61 string `iso8583:"61"`
+}
+
+// ToMap returns the values of AuthorizationAdditionalDataSubelements as expected by its serdes.
+func (m *AuthorizationAdditionalDataSubelements) ToMap() serdes.Map {
+	values := serdes.Map{}
+	if m.Field21 != "" {
+		values[AuthorizationAdditionalDataSubelementsFieldField21] = m.Field21
+	}
+	if m.Field61 != "" {
+		values[AuthorizationAdditionalDataSubelementsFieldField61] = m.Field61
+	}
+	return values
+}
+
+// FromMap sets the fields of AuthorizationAdditionalDataSubelements from the values decoded by its serdes.
+func (m *AuthorizationAdditionalDataSubelements) FromMap(values serdes.Map) error {
+	if err := authorizationString(values, AuthorizationAdditionalDataSubelementsFieldField21, &m.Field21); err != nil {
+		return err
+	}
+	if err := authorizationString(values, AuthorizationAdditionalDataSubelementsFieldField61, &m.Field61); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate checks the numeric fields of AuthorizationAdditionalDataSubelements and of its composite fields.
+func (m *AuthorizationAdditionalDataSubelements) Validate() error {
+	return nil
+}
+
+// AuthorizationICCData - ICC data.
+type AuthorizationICCData struct {
+	// 9f26 - Application cryptogram.
+	ApplicationCryptogram string `iso8583:"9f26"`
+	// 9f36 - Application transaction counter.
+	ApplicationTransactionCounter string `iso8583:"9f36"`
+}
+
+// ToMap returns the values of AuthorizationICCData as expected by its serdes.
+func (m *AuthorizationICCData) ToMap() serdes.Map {
+	values := serdes.Map{}
+	if m.ApplicationCryptogram != "" {
+		values[AuthorizationICCDataFieldApplicationCryptogram] = m.ApplicationCryptogram
+	}
+	if m.ApplicationTransactionCounter != "" {
+		values[AuthorizationICCDataFieldApplicationTransactionCounter] = m.ApplicationTransactionCounter
+	}
+	return values
+}
+
+// FromMap sets the fields of AuthorizationICCData from the values decoded by its serdes.
+func (m *AuthorizationICCData) FromMap(values serdes.Map) error {
+	if err := authorizationString(values, AuthorizationICCDataFieldApplicationCryptogram, &m.ApplicationCryptogram); err != nil {
+		return err
+	}
+	if err := authorizationString(values, AuthorizationICCDataFieldApplicationTransactionCounter, &m.ApplicationTransactionCounter); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate checks the numeric fields of AuthorizationICCData and of its composite fields.
+func (m *AuthorizationICCData) Validate() error {
+	return nil
+}
+
+// AuthorizationCustomPaymentServiceFields - Custom payment service fields.
+type AuthorizationCustomPaymentServiceFields struct {
+	// 1 - Authorization characteristics indicator.
+	AuthorizationCharacteristicsIndicator string `iso8583:"1"`
+	// 2 - Transaction identifier.
+	TransactionIdentifier AuthorizationNumeric `iso8583:"2"`
+}
+
+// ToMap returns the values of AuthorizationCustomPaymentServiceFields as expected by its serdes.
+func (m *AuthorizationCustomPaymentServiceFields) ToMap() serdes.Map {
+	values := serdes.Map{}
+	if m.AuthorizationCharacteristicsIndicator != "" {
+		values[AuthorizationCustomPaymentServiceFieldsFieldAuthorizationCharacteristicsIndicator] = m.AuthorizationCharacteristicsIndicator
+	}
+	if m.TransactionIdentifier != "" {
+		values[AuthorizationCustomPaymentServiceFieldsFieldTransactionIdentifier] = string(m.TransactionIdentifier)
+	}
+	return values
+}
+
+// FromMap sets the fields of AuthorizationCustomPaymentServiceFields from the values decoded by its serdes.
+func (m *AuthorizationCustomPaymentServiceFields) FromMap(values serdes.Map) error {
+	if err := authorizationString(values, AuthorizationCustomPaymentServiceFieldsFieldAuthorizationCharacteristicsIndicator, &m.AuthorizationCharacteristicsIndicator); err != nil {
+		return err
+	}
+	if err := authorizationNumeric(values, AuthorizationCustomPaymentServiceFieldsFieldTransactionIdentifier, &m.TransactionIdentifier); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate checks the numeric fields of AuthorizationCustomPaymentServiceFields and of its composite fields.
+func (m *AuthorizationCustomPaymentServiceFields) Validate() error {
+	if _, err := NewAuthorizationNumeric(string(m.TransactionIdentifier)); err != nil {
+		return fmt.Errorf("field %s: %w", AuthorizationCustomPaymentServiceFieldsFieldTransactionIdentifier, err)
+	}
+	return nil
+}
+
+// Pack serializes the message with AuthorizationSpec.
+func (m *Authorization) Pack() ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := AuthorizationSpec.Serialize(m.ToMap())
+	if err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+// Unpack deserializes the data with AuthorizationSpec into the message.
+func (m *Authorization) Unpack(data []byte) error {
+	value, err := AuthorizationSpec.Deserialize(bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+
+	mapValue, ok := value.(serdes.Map)
+	if !ok {
+		return fmt.Errorf("invalid value [%T], expected: %T", value, serdes.Map{})
+	}
+
+	*m = Authorization{}
+	return m.FromMap(mapValue)
+}
+
+func authorizationString(values serdes.Map, key string, dst *string) error {
+	value, exists := values[key]
+	if !exists || value == nil {
+		return nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("field %s: invalid value [%T], expected: string", key, value)
+	}
+
+	*dst = str
+	return nil
+}
+
+func authorizationMap(values serdes.Map, key string) (serdes.Map, bool, error) {
+	value, exists := values[key]
+	if !exists || value == nil {
+		return nil, false, nil
+	}
+
+	mapValue, ok := value.(serdes.Map)
+	if !ok {
+		return nil, false, fmt.Errorf("field %s: invalid value [%T], expected: %T", key, value, serdes.Map{})
+	}
+
+	return mapValue, true, nil
+}
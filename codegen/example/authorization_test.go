@@ -0,0 +1,66 @@
+package example_test
+
+import (
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/codegen/example"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Authorization_Pack_Unpack(t *testing.T) {
+	in := example.Authorization{
+		MessageTypeIdentifier:  "0100",
+		PrimaryAccountNumber:   "4111111111111111",
+		ProcessingCode:         "000000",
+		AmountTransaction:      "1500",
+		SystemTraceAuditNumber: "000042",
+		AdditionalData: &example.AuthorizationAdditionalData{
+			TransactionCategoryCode: "R",
+			Subelements:             &example.AuthorizationAdditionalDataSubelements{Field21: "01010"},
+		},
+		ICCData: &example.AuthorizationICCData{ApplicationCryptogram: "0102030405060708"},
+		CustomPaymentServiceFields: &example.AuthorizationCustomPaymentServiceFields{
+			AuthorizationCharacteristicsIndicator: "Y",
+			TransactionIdentifier:                 "012345678901234",
+		},
+	}
+
+	data, err := in.Pack()
+	assert.NoError(t, err)
+
+	var out example.Authorization
+	assert.NoError(t, out.Unpack(data))
+	assert.Equal(t, in, out)
+}
+
+func Test_Authorization_FromMap_Invalid_Type(t *testing.T) {
+	var msg example.Authorization
+	err := msg.FromMap(map[string]interface{}{example.AuthorizationFieldAdditionalData: "invalid"})
+	assert.EqualError(t, err, "field 48: invalid value [string], expected: map[string]interface {}")
+}
+
+func Test_Authorization_Numeric(t *testing.T) {
+	processingCode, err := example.NewAuthorizationNumeric("000000")
+	assert.NoError(t, err)
+	assert.Equal(t, example.AuthorizationNumeric("000000"), processingCode)
+
+	_, err = example.NewAuthorizationNumeric("00a000")
+	assert.EqualError(t, err, "invalid numeric character 'a'")
+
+	msg := example.Authorization{MessageTypeIdentifier: "0100", ProcessingCode: "00a000"}
+	_, err = msg.Pack()
+	assert.EqualError(t, err, "field 3: invalid numeric character 'a'")
+
+	msg = example.Authorization{
+		MessageTypeIdentifier: "0100",
+		CustomPaymentServiceFields: &example.AuthorizationCustomPaymentServiceFields{
+			TransactionIdentifier: "01234567890123x",
+		},
+	}
+	_, err = msg.Pack()
+	assert.EqualError(t, err, "field 62: field 2: invalid numeric character 'x'")
+
+	err = msg.FromMap(map[string]interface{}{example.AuthorizationFieldMessageTypeIdentifier: "01x0"})
+	assert.EqualError(t, err, "field mti: invalid numeric character 'x'")
+}
@@ -0,0 +1,4 @@
+// Package example contains the code generated by iso8583gen for codegen/testdata/message.json.
+package example
+
+//go:generate go run ../../cmd/iso8583gen -spec ../testdata/message.json -package example -type Authorization -o authorization_gen.go
@@ -0,0 +1,185 @@
+package codegen
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// specLiteral returns the Go expression that builds the spec, and if it requires the encoding/binary package.
+func specLiteral(spec serdes.Serdes) (string, bool, error) {
+	w := &literalWriter{}
+	literal, err := w.literal("", spec)
+	if err != nil {
+		return "", false, err
+	}
+	return literal, w.usesBinary, nil
+}
+
+type literalWriter struct {
+	usesBinary bool
+}
+
+func (w *literalWriter) literal(path string, s serdes.Serdes) (string, error) {
+	switch value := s.(type) {
+	case types.Bcd:
+		return inline("Bcd", value.Desc, maskAttr(value.Mask), intAttr("NumDigits", value.NumDigits), boolAttr("NotPadded", value.NotPadded)), nil
+	case types.Ebcdic:
+		return inline("Ebcdic", value.Desc, maskAttr(value.Mask), intAttr("NumDigits", value.NumDigits)), nil
+	case types.EbcdicNumeric:
+		return inline("EbcdicNumeric", value.Desc, maskAttr(value.Mask), intAttr("NumDigits", value.NumDigits)), nil
+	case types.Ascii:
+		return inline("Ascii", value.Desc, maskAttr(value.Mask), intAttr("NumDigits", value.NumDigits)), nil
+	case types.AsciiNumeric:
+		return inline("AsciiNumeric", value.Desc, maskAttr(value.Mask), intAttr("NumDigits", value.NumDigits)), nil
+	case types.Raw:
+		return inline("Raw", value.Desc, maskAttr(value.Mask), intAttr("NumBytes", value.NumBytes)), nil
+	case types.Byte:
+		return inline("Byte", value.Desc, maskAttr(value.Mask)), nil
+	case types.Word:
+		var order string
+		switch value.Order {
+		case binary.BigEndian:
+			order = "Order: binary.BigEndian"
+		case binary.LittleEndian:
+			order = "Order: binary.LittleEndian"
+		default:
+			return "", Error{Message: "unsupported byte order", Path: path}
+		}
+		w.usesBinary = true
+		return inline("Word", value.Desc, maskAttr(value.Mask), order), nil
+	case types.VarLength:
+		length, err := w.literal(serdes.JoinPath(path, "length"), value.Length)
+		if err != nil {
+			return "", err
+		}
+
+		data, err := w.literal(path, value.Data)
+		if err != nil {
+			return "", err
+		}
+		return inline("VarLength", value.Desc, "Length: "+length, "Data: "+data), nil
+	case types.List:
+		items, err := w.items(path, value.Items)
+		if err != nil {
+			return "", err
+		}
+		return multiline("List", value.Desc, items), nil
+	case types.TLV:
+		items, err := w.items(path, value.Items)
+		if err != nil {
+			return "", err
+		}
+		return multiline("TLV", value.Desc, intAttr("SizeLen", value.SizeLen), intAttr("SizeTag", value.SizeTag),
+			boolAttr("Ascii", value.Ascii), items), nil
+	case types.BerTLV:
+		items, err := w.items(path, value.Items)
+		if err != nil {
+			return "", err
+		}
+		return multiline("BerTLV", value.Desc, intAttr("SizeLen", value.SizeLen), items), nil
+	case types.BitMapped:
+		bitmap := fmt.Sprintf("Bitmap: types.Bitmap{%s}", strings.Join(nonEmpty(
+			intAttr("BlockSize", value.Bitmap.BlockSize),
+			[]string{intAttr("NumBits", value.Bitmap.NumBits), boolAttr("Hex", value.Bitmap.Hex)},
+		), ", "))
+		mapping, err := w.mapping(path, value.Mapping)
+		if err != nil {
+			return "", err
+		}
+		return multiline("BitMapped", value.Desc, bitmap, mapping), nil
+	case nil:
+		return "", Error{Message: "missing serdes", Path: path}
+	}
+
+	return "", Error{Message: fmt.Sprintf("unsupported serdes %s", s.Name()), Path: path}
+}
+
+func (w *literalWriter) items(path string, items []types.Field) (string, error) {
+	var out strings.Builder
+	out.WriteString("Items: []types.Field{\n")
+	for _, item := range items {
+		literal, err := w.literal(serdes.JoinPath(path, item.Name), item.SerDes)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&out, "{Name: %q, SerDes: %s},\n", item.Name, literal)
+	}
+	out.WriteString("}")
+	return out.String(), nil
+}
+
+func (w *literalWriter) mapping(path string, mapping map[int]serdes.Serdes) (string, error) {
+	bits := make([]int, 0, len(mapping))
+	for bit := range mapping {
+		bits = append(bits, bit)
+	}
+	sort.Ints(bits)
+
+	var out strings.Builder
+	out.WriteString("Mapping: map[int]serdes.Serdes{\n")
+	for _, bit := range bits {
+		literal, err := w.literal(serdes.JoinPath(path, fmt.Sprint(bit)), mapping[bit])
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&out, "%d: %s,\n", bit, literal)
+	}
+	out.WriteString("}")
+	return out.String(), nil
+}
+
+func inline(typeName string, desc types.Desc, attrs ...string) string {
+	return fmt.Sprintf("types.%s{%s}", typeName, strings.Join(nonEmpty(descAttr(desc), attrs), ", "))
+}
+
+func multiline(typeName string, desc types.Desc, attrs ...string) string {
+	return fmt.Sprintf("types.%s{\n%s,\n}", typeName, strings.Join(nonEmpty(descAttr(desc), attrs), ",\n"))
+}
+
+func descAttr(desc types.Desc) string {
+	if desc == "" {
+		return ""
+	}
+	return fmt.Sprintf("Desc: %q", string(desc))
+}
+
+func maskAttr(mask types.Mask) string {
+	switch mask {
+	case types.NoMask:
+		return ""
+	case types.MaskPAN:
+		return "Mask: types.MaskPAN"
+	case types.MaskAll:
+		return "Mask: types.MaskAll"
+	}
+	return fmt.Sprintf("Mask: %q", string(mask))
+}
+
+func intAttr(name string, value int) string {
+	if value == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s: %d", name, value)
+}
+
+func boolAttr(name string, value bool) string {
+	if !value {
+		return ""
+	}
+	return name + ": true"
+}
+
+func nonEmpty(first string, attrs []string) []string {
+	out := make([]string, 0, len(attrs)+1)
+	for _, attr := range append([]string{first}, attrs...) {
+		if attr != "" {
+			out = append(out, attr)
+		}
+	}
+	return out
+}
@@ -0,0 +1,247 @@
+// Package diff compares two serdes trees and reports the fields added, removed and changed between them.
+//
+// The fields are reported with the paths of the values they decode: the bit numbers of a BitMapped, the item names of
+// a List and the tags of a TLV or BerTLV, joined by serdes.PathSeparator. VarLength fields are transparent, the changes
+// of their length prefix are reported as attributes prefixed by "length.", and the fields of anonymous List items are
+// reported as fields of the List.
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/spec"
+)
+
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Difference is a field added, removed or with a changed attribute. Old and New are the type of the field when it was
+// added or removed, and the attribute values when it changed.
+type Difference struct {
+	Path      string `json:"path"`
+	Kind      Kind   `json:"kind"`
+	Attribute string `json:"attribute,omitempty"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+func (d Difference) String() string {
+	path := d.Path
+	if path == "" {
+		path = "(root)"
+	}
+
+	switch d.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s: %s", path, d.New)
+	case Removed:
+		return fmt.Sprintf("- %s: %s", path, d.Old)
+	}
+	return fmt.Sprintf("~ %s: %s: %s -> %s", path, d.Attribute, d.Old, d.New)
+}
+
+// Compare reports the differences from the old to the new serdes tree, the trees are exported with the default
+// registry of the spec package.
+func Compare(old, new serdes.Serdes) ([]Difference, error) {
+	oldNode, err := spec.Export(old)
+	if err != nil {
+		return nil, err
+	}
+
+	newNode, err := spec.Export(new)
+	if err != nil {
+		return nil, err
+	}
+
+	return CompareNodes(oldNode, newNode), nil
+}
+
+// CompareNodes reports the differences from the old to the new spec definition.
+func CompareNodes(old, new *spec.Node) []Difference {
+	c := &comparer{}
+	c.compare("", old, new)
+	return c.diffs
+}
+
+type comparer struct {
+	diffs []Difference
+}
+
+func (c *comparer) add(d Difference) {
+	c.diffs = append(c.diffs, d)
+}
+
+func (c *comparer) compare(path string, old, new *spec.Node) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		c.add(Difference{Path: path, Kind: Added, New: summary(new)})
+		return
+	case new == nil:
+		c.add(Difference{Path: path, Kind: Removed, Old: summary(old)})
+		return
+	}
+
+	if !strings.EqualFold(old.Type, new.Type) {
+		c.add(Difference{Path: path, Kind: Changed, Attribute: "type", Old: old.Type, New: new.Type})
+		return
+	}
+
+	c.attributes(path, "", old, new)
+	if old.Length != nil || new.Length != nil {
+		c.prefix(path, old.Length, new.Length)
+	}
+
+	c.compare(path, old.Data, new.Data)
+	c.fields(path, old.Fields, new.Fields)
+	c.items(path, old.Items, new.Items)
+}
+
+// prefix compares the length prefixes of VarLength fields.
+func (c *comparer) prefix(path string, old, new *spec.Node) {
+	switch {
+	case old == nil || new == nil:
+		c.add(Difference{Path: path, Kind: Changed, Attribute: "length", Old: summary(old), New: summary(new)})
+	case !strings.EqualFold(old.Type, new.Type):
+		c.add(Difference{Path: path, Kind: Changed, Attribute: "length.type", Old: old.Type, New: new.Type})
+	default:
+		c.attributes(path, "length.", old, new)
+	}
+}
+
+func (c *comparer) attributes(path, prefix string, old, new *spec.Node) {
+	attribute := func(name, oldValue, newValue string) {
+		if oldValue != newValue {
+			c.add(Difference{Path: path, Kind: Changed, Attribute: prefix + name, Old: oldValue, New: newValue})
+		}
+	}
+
+	attribute("desc", old.Desc, new.Desc)
+	attribute("mask", old.Mask, new.Mask)
+	attribute("num_digits", strconv.Itoa(old.NumDigits), strconv.Itoa(new.NumDigits))
+	attribute("num_bytes", strconv.Itoa(old.NumBytes), strconv.Itoa(new.NumBytes))
+	attribute("not_padded", strconv.FormatBool(old.NotPadded), strconv.FormatBool(new.NotPadded))
+	attribute("order", old.Order, new.Order)
+	attribute("size_len", strconv.Itoa(old.SizeLen), strconv.Itoa(new.SizeLen))
+	attribute("size_tag", strconv.Itoa(old.SizeTag), strconv.Itoa(new.SizeTag))
+	attribute("ascii", strconv.FormatBool(old.Ascii), strconv.FormatBool(new.Ascii))
+	attribute("params", fmt.Sprint(old.Params), fmt.Sprint(new.Params))
+
+	oldBitmap, newBitmap := old.Bitmap, new.Bitmap
+	if oldBitmap == nil {
+		oldBitmap = &spec.BitmapNode{}
+	}
+	if newBitmap == nil {
+		newBitmap = &spec.BitmapNode{}
+	}
+	attribute("bitmap.block_size", strconv.Itoa(oldBitmap.BlockSize), strconv.Itoa(newBitmap.BlockSize))
+	attribute("bitmap.num_bits", strconv.Itoa(oldBitmap.NumBits), strconv.Itoa(newBitmap.NumBits))
+	attribute("bitmap.hex", strconv.FormatBool(oldBitmap.Hex), strconv.FormatBool(newBitmap.Hex))
+}
+
+// fields compares the fields of BitMapped nodes, sorted by bit number.
+func (c *comparer) fields(path string, old, new map[string]*spec.Node) {
+	keys := make(map[string]bool, len(old)+len(new))
+	for key := range old {
+		keys[key] = true
+	}
+	for key := range new {
+		keys[key] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, errA := strconv.Atoi(sorted[i])
+		b, errB := strconv.Atoi(sorted[j])
+		if errA != nil || errB != nil {
+			return sorted[i] < sorted[j]
+		}
+		return a < b
+	})
+
+	for _, key := range sorted {
+		c.compare(serdes.JoinPath(path, key), old[key], new[key])
+	}
+}
+
+// items compares the named items by name and the anonymous items by position, the anonymous items are compared with
+// the path of the list.
+func (c *comparer) items(path string, old, new []*spec.Node) {
+	oldNamed, oldAnonymous := split(old)
+	newNamed, newAnonymous := split(new)
+
+	for _, node := range old {
+		if node.Name != "" {
+			c.compare(serdes.JoinPath(path, node.Name), node, newNamed[node.Name])
+		}
+	}
+
+	for _, node := range new {
+		if node.Name != "" && oldNamed[node.Name] == nil {
+			c.compare(serdes.JoinPath(path, node.Name), nil, node)
+		}
+	}
+
+	for index := 0; index < len(oldAnonymous) || index < len(newAnonymous); index++ {
+		var oldNode, newNode *spec.Node
+		if index < len(oldAnonymous) {
+			oldNode = oldAnonymous[index]
+		}
+		if index < len(newAnonymous) {
+			newNode = newAnonymous[index]
+		}
+		c.compare(path, oldNode, newNode)
+	}
+
+	if oldOrder, newOrder := order(old, newNamed), order(new, oldNamed); oldOrder != newOrder {
+		c.add(Difference{Path: path, Kind: Changed, Attribute: "items.order", Old: oldOrder, New: newOrder})
+	}
+}
+
+func split(nodes []*spec.Node) (map[string]*spec.Node, []*spec.Node) {
+	named := make(map[string]*spec.Node, len(nodes))
+	var anonymous []*spec.Node
+	for _, node := range nodes {
+		if node.Name == "" {
+			anonymous = append(anonymous, node)
+			continue
+		}
+		named[node.Name] = node
+	}
+	return named, anonymous
+}
+
+// order returns the names of the items that are in both lists, in the order of the nodes.
+func order(nodes []*spec.Node, other map[string]*spec.Node) string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Name != "" && other[node.Name] != nil {
+			names = append(names, node.Name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+func summary(node *spec.Node) string {
+	if node == nil {
+		return "none"
+	}
+
+	if node.Desc == "" {
+		return node.Type
+	}
+	return fmt.Sprintf("%s %q", node.Type, node.Desc)
+}
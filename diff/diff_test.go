@@ -0,0 +1,101 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/diff"
+	"github.com/mercadolibre/go-iso8583/overlay"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/spec"
+	"github.com/mercadolibre/go-iso8583/specs"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Compare_Same_Spec(t *testing.T) {
+	diffs, err := diff.Compare(specs.Mastercard(), specs.Mastercard())
+	assert.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
+func Test_Compare_Overlay(t *testing.T) {
+	base := specs.Mastercard()
+	derived, _, err := overlay.Apply(base,
+		overlay.Set("2", types.VarLength{
+			Desc:   "Primary account number",
+			Length: types.EbcdicNumeric{NumDigits: 3},
+			Data:   types.EbcdicNumeric{},
+		}),
+		overlay.Set("4", types.Bcd{Desc: "Amount, transaction", NumDigits: 12}),
+		overlay.Set("43", types.Ebcdic{Desc: "Card acceptor name/location", NumDigits: 99}),
+		overlay.Set("48.se.99", types.Ebcdic{Desc: "Host data"}),
+		overlay.Remove("127"),
+	)
+	assert.NoError(t, err)
+
+	diffs, err := diff.Compare(base, derived)
+	assert.NoError(t, err)
+	assert.Equal(t, []diff.Difference{
+		{Path: "2", Kind: diff.Changed, Attribute: "length.num_digits", Old: "2", New: "3"},
+		{Path: "2", Kind: diff.Changed, Attribute: "mask", Old: "pan", New: ""},
+		{Path: "4", Kind: diff.Changed, Attribute: "type", Old: "ebcdic_numeric", New: "bcd"},
+		{Path: "43", Kind: diff.Changed, Attribute: "num_digits", Old: "40", New: "99"},
+		{Path: "48.se.99", Kind: diff.Added, New: `ebcdic "Host data"`},
+		{Path: "127", Kind: diff.Removed, Old: `var_length "Private data"`},
+	}, diffs)
+
+	assert.Equal(t, "~ 2: length.num_digits: 2 -> 3", diffs[0].String())
+	assert.Equal(t, "~ 2: mask: pan -> ", diffs[1].String())
+	assert.Equal(t, `+ 48.se.99: ebcdic "Host data"`, diffs[4].String())
+	assert.Equal(t, `- 127: var_length "Private data"`, diffs[5].String())
+}
+
+func Test_Compare_Bitmap_And_Order(t *testing.T) {
+	old := types.List{Items: []types.Field{
+		{Name: "a", SerDes: types.Ascii{NumDigits: 1}},
+		{Name: "b", SerDes: types.Ascii{NumDigits: 1}},
+		{SerDes: types.BitMapped{Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true}, Mapping: map[int]serdes.Serdes{}}},
+	}}
+	new := types.List{Items: []types.Field{
+		{Name: "b", SerDes: types.Ascii{NumDigits: 1}},
+		{Name: "a", SerDes: types.Ascii{NumDigits: 1}},
+		{SerDes: types.BitMapped{Bitmap: types.Bitmap{BlockSize: 64, NumBits: 192}, Mapping: map[int]serdes.Serdes{}}},
+	}}
+
+	diffs, err := diff.Compare(old, new)
+	assert.NoError(t, err)
+	assert.Equal(t, []diff.Difference{
+		{Path: "", Kind: diff.Changed, Attribute: "bitmap.num_bits", Old: "128", New: "192"},
+		{Path: "", Kind: diff.Changed, Attribute: "bitmap.hex", Old: "true", New: "false"},
+		{Path: "", Kind: diff.Changed, Attribute: "items.order", Old: "a,b", New: "b,a"},
+	}, diffs)
+	assert.Equal(t, "~ (root): items.order: a,b -> b,a", diffs[2].String())
+}
+
+func Test_Compare_Nodes(t *testing.T) {
+	old, err := spec.Parse([]byte(`{"type": "word", "order": "big"}`))
+	assert.NoError(t, err)
+	new, err := spec.Parse([]byte(`{"type": "word", "order": "little"}`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []diff.Difference{
+		{Kind: diff.Changed, Attribute: "order", Old: "big", New: "little"},
+	}, diff.CompareNodes(old, new))
+}
+
+func Test_Compare_Error(t *testing.T) {
+	_, err := diff.Compare(&serdes.Mock{}, specs.Mastercard())
+	assert.Error(t, err)
+}
+
+func Test_Compare_Mask(t *testing.T) {
+	old := types.List{Items: []types.Field{{Name: "pan", SerDes: types.Ascii{}}}}
+	new := types.List{Items: []types.Field{{Name: "pan", SerDes: types.Ascii{Mask: types.MaskPAN}}}}
+
+	diffs, err := diff.Compare(old, new)
+	assert.NoError(t, err)
+	assert.Equal(t, []diff.Difference{
+		{Path: "pan", Kind: diff.Changed, Attribute: "mask", Old: "", New: "pan"},
+	}, diffs)
+}
@@ -0,0 +1,277 @@
+// Package docgen generates the field reference of a spec as a Markdown or HTML table, so the integration documents
+// are generated from the same serdes tree that packs the messages.
+//
+// Every named field is a row: the bit numbers of a BitMapped, the items of a List and the tags of a TLV or BerTLV.
+// The subfields follow their field, with their full value path, VarLength fields are described by their length prefix
+// and their data, and the fields of anonymous List items are listed as fields of the List.
+package docgen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+type Options struct {
+	// Format of the document, by default Markdown.
+	Format Format
+	// Title is the heading of the document, no heading is written when it is empty.
+	Title string
+}
+
+type Error struct {
+	Message string `json:"message"`
+	Path    string `json:"path"`
+}
+
+func (err Error) Error() string {
+	if err.Path != "" {
+		return fmt.Sprintf("%s: path: %s.", err.Message, err.Path)
+	}
+	return err.Message
+}
+
+// Row is the reference of a field.
+type Row struct {
+	// Path is the path of the value of the field, joined by serdes.PathSeparator.
+	Path string `json:"path"`
+	// Field is the bit number, item name or tag of the field, the last segment of the path.
+	Field string `json:"field"`
+	// Depth is the number of parent fields, 0 for the fields of the root.
+	Depth       int    `json:"depth"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Encoding    string `json:"encoding"`
+	Length      string `json:"length"`
+}
+
+// Rows returns the reference of the fields of the spec in encoding order.
+func Rows(spec serdes.Serdes) []Row {
+	var rows []Row
+	_ = types.Walk(spec, func(path string, child types.Child, _ serdes.Serdes) error {
+		if child.Kind != types.FieldChild || child.Key == "" {
+			return nil
+		}
+
+		segments := serdes.SplitPath(path)
+		rows = append(rows, Row{
+			Path:        path,
+			Field:       child.Key,
+			Depth:       len(segments) - 1,
+			Description: description(child.SerDes),
+			Type:        data(child.SerDes).Name(),
+			Encoding:    encoding(data(child.SerDes)),
+			Length:      length(child.SerDes),
+		})
+		return nil
+	})
+	return rows
+}
+
+// Generate returns the field reference of the spec in the format of the options.
+func Generate(spec serdes.Serdes, opts Options) ([]byte, error) {
+	rows := Rows(spec)
+	switch opts.Format {
+	case "", Markdown:
+		return markdown(opts.Title, rows), nil
+	case HTML:
+		return html(opts.Title, rows)
+	}
+	return nil, Error{Message: fmt.Sprintf("unknown format %q", opts.Format)}
+}
+
+func markdown(title string, rows []Row) []byte {
+	out := new(bytes.Buffer)
+	if title != "" {
+		fmt.Fprintf(out, "# %s\n\n", title)
+	}
+
+	out.WriteString("| Field | Description | Type | Encoding | Length |\n")
+	out.WriteString("|---|---|---|---|---|\n")
+	for _, row := range rows {
+		fmt.Fprintf(out, "| %s | %s | %s | %s | %s |\n", cell(row.Path), cell(row.Description), cell(row.Type),
+			cell(row.Encoding), cell(row.Length))
+	}
+	return out.Bytes()
+}
+
+func cell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+var htmlTemplate = template.Must(template.New("reference").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+{{- if .Title}}
+<title>{{.Title}}</title>
+{{- end}}
+</head>
+<body>
+{{- if .Title}}
+<h1>{{.Title}}</h1>
+{{- end}}
+<table>
+<thead>
+<tr><th>Field</th><th>Description</th><th>Type</th><th>Encoding</th><th>Length</th></tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr class="depth-{{.Depth}}"><td>{{.Path}}</td><td>{{.Description}}</td><td>{{.Type}}</td><td>{{.Encoding}}</td><td>{{.Length}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+func html(title string, rows []Row) ([]byte, error) {
+	out := new(bytes.Buffer)
+	err := htmlTemplate.Execute(out, struct {
+		Title string
+		Rows  []Row
+	}{title, rows})
+	if err != nil {
+		return nil, Error{Message: fmt.Sprintf("error executing html template: %v", err)}
+	}
+	return out.Bytes(), nil
+}
+
+// data returns the data of the VarLength fields.
+func data(s serdes.Serdes) serdes.Serdes {
+	if varLen, ok := s.(types.VarLength); ok && varLen.Data != nil {
+		return data(varLen.Data)
+	}
+	return s
+}
+
+// description returns the description of the field, or of its data when the VarLength has none.
+func description(s serdes.Serdes) string {
+	if described, ok := s.(types.Described); ok && described.Description() != "" {
+		return described.Description()
+	}
+
+	if varLen, ok := s.(types.VarLength); ok && varLen.Data != nil {
+		return description(varLen.Data)
+	}
+	return ""
+}
+
+func encoding(s serdes.Serdes) string {
+	switch value := s.(type) {
+	case types.Bcd:
+		return "BCD"
+	case types.Ebcdic:
+		return "EBCDIC"
+	case types.EbcdicNumeric:
+		return "EBCDIC numeric"
+	case types.Ascii:
+		return "ASCII"
+	case types.AsciiNumeric:
+		return "ASCII numeric"
+	case types.Raw, types.Byte:
+		return "binary"
+	case types.Word:
+		switch value.Order {
+		case binary.BigEndian:
+			return "binary, big endian"
+		case binary.LittleEndian:
+			return "binary, little endian"
+		}
+		return "binary"
+	case types.BitMapped:
+		if value.Bitmap.Hex {
+			return "hex bitmap"
+		}
+		return "binary bitmap"
+	case types.List:
+		return "positional"
+	case types.TLV:
+		encoding := "EBCDIC"
+		if value.Ascii {
+			encoding = "ASCII"
+		}
+		return fmt.Sprintf("TLV, %d tag and %d length %s digits", orDefault(value.SizeTag), orDefault(value.SizeLen), encoding)
+	case types.BerTLV:
+		return "BER-TLV"
+	}
+	return ""
+}
+
+func orDefault(size int) int {
+	if size == 0 {
+		return 2
+	}
+	return size
+}
+
+// length describes the fixed size of a field or the length prefix of a VarLength.
+func length(s serdes.Serdes) string {
+	switch value := s.(type) {
+	case types.VarLength:
+		return prefix(value.Length)
+	case types.Bcd:
+		return digits(value.NumDigits, "digits")
+	case types.Ebcdic:
+		return digits(value.NumDigits, "chars")
+	case types.EbcdicNumeric:
+		return digits(value.NumDigits, "digits")
+	case types.Ascii:
+		return digits(value.NumDigits, "chars")
+	case types.AsciiNumeric:
+		return digits(value.NumDigits, "digits")
+	case types.Byte:
+		return "1 byte"
+	case types.BitMapped:
+		return fmt.Sprintf("%d bits", value.Bitmap.NumBits)
+	}
+
+	if introspectable, ok := s.(types.Introspectable); ok {
+		if size, fixed := introspectable.FixedSize(); fixed {
+			return digits(size, "bytes")
+		}
+	}
+	return "variable"
+}
+
+func digits(size int, unit string) string {
+	if size <= 0 {
+		return "variable"
+	}
+	return strconv.Itoa(size) + " " + unit
+}
+
+// prefix describes the length prefix of a VarLength, the prefixes of n digits are described as n L followed by VAR.
+func prefix(s serdes.Serdes) string {
+	var numDigits int
+	switch value := s.(type) {
+	case types.Bcd:
+		numDigits = value.NumDigits
+	case types.AsciiNumeric:
+		numDigits = value.NumDigits
+	case types.EbcdicNumeric:
+		numDigits = value.NumDigits
+	case types.Byte:
+		return "VAR, 1 byte length"
+	case types.Word:
+		return "VAR, 2 bytes length"
+	}
+
+	if numDigits <= 0 {
+		return "VAR"
+	}
+	return strings.Repeat("L", numDigits) + "VAR"
+}
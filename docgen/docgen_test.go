@@ -0,0 +1,64 @@
+package docgen_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/docgen"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSpec() serdes.Serdes {
+	return types.List{Items: []types.Field{
+		{Name: "mti", SerDes: types.Bcd{Desc: "MTI", NumDigits: 4}},
+		{SerDes: types.BitMapped{
+			Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true},
+			Mapping: map[int]serdes.Serdes{
+				2: types.VarLength{Desc: "PAN", Length: types.AsciiNumeric{NumDigits: 2}, Data: types.AsciiNumeric{}},
+				48: types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: types.TLV{
+					Desc:  "Additional data | private",
+					Items: []types.Field{{Name: "01", SerDes: types.Ebcdic{Desc: "Code", NumDigits: 3}}},
+				}},
+				52: types.Raw{Desc: "PIN block", NumBytes: 8},
+			},
+		}},
+	}}
+}
+
+func Test_Rows(t *testing.T) {
+	assert.Equal(t, []docgen.Row{
+		{Path: "mti", Field: "mti", Description: "MTI", Type: "bcd", Encoding: "BCD", Length: "4 digits"},
+		{Path: "2", Field: "2", Description: "PAN", Type: "ascii_numeric", Encoding: "ASCII numeric", Length: "LLVAR"},
+		{Path: "48", Field: "48", Description: "Additional data | private", Type: "tlv", Encoding: "TLV, 2 tag and 2 length EBCDIC digits", Length: "VAR, 2 bytes length"},
+		{Path: "48.01", Field: "01", Depth: 1, Description: "Code", Type: "ebcdic", Encoding: "EBCDIC", Length: "3 chars"},
+		{Path: "52", Field: "52", Description: "PIN block", Type: "raw", Encoding: "binary", Length: "8 bytes"},
+	}, docgen.Rows(testSpec()))
+}
+
+func Test_Generate_Markdown(t *testing.T) {
+	doc, err := docgen.Generate(testSpec(), docgen.Options{Title: "Authorization"})
+	assert.NoError(t, err)
+	assert.Equal(t, `# Authorization
+
+| Field | Description | Type | Encoding | Length |
+|---|---|---|---|---|
+| mti | MTI | bcd | BCD | 4 digits |
+| 2 | PAN | ascii_numeric | ASCII numeric | LLVAR |
+| 48 | Additional data \| private | tlv | TLV, 2 tag and 2 length EBCDIC digits | VAR, 2 bytes length |
+| 48.01 | Code | ebcdic | EBCDIC | 3 chars |
+| 52 | PIN block | raw | binary | 8 bytes |
+`, string(doc))
+}
+
+func Test_Generate_HTML(t *testing.T) {
+	doc, err := docgen.Generate(testSpec(), docgen.Options{Format: docgen.HTML, Title: "<Authorization>"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(doc), "<h1>&lt;Authorization&gt;</h1>")
+	assert.Contains(t, string(doc), `<tr class="depth-1"><td>48.01</td><td>Code</td><td>ebcdic</td><td>EBCDIC</td><td>3 chars</td></tr>`)
+
+	_, err = docgen.Generate(testSpec(), docgen.Options{Format: "pdf"})
+	assert.EqualError(t, err, `unknown format "pdf"`)
+}
@@ -0,0 +1,53 @@
+// Package testspec has the spec and the message shared by the tests of the packages that read encoded messages, like
+// lazy, patch and trace.
+package testspec
+
+import (
+	"encoding/binary"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// Spec returns a List with the MTI and a BitMapped whose fields have fixed sizes, length prefixes, a nested List with a
+// TLV and a BerTLV.
+func Spec() types.List {
+	return types.List{Items: []types.Field{
+		{Name: "mti", SerDes: types.AsciiNumeric{NumDigits: 4}},
+		{SerDes: types.BitMapped{
+			Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true},
+			Mapping: map[int]serdes.Serdes{
+				2:  types.VarLength{Length: types.Byte{}, Data: types.Bcd{}},
+				3:  types.AsciiNumeric{NumDigits: 6},
+				32: types.VarLength{Length: types.AsciiNumeric{NumDigits: 2}, Data: types.AsciiNumeric{}},
+				39: types.Ascii{NumDigits: 2},
+				48: types.VarLength{Length: types.AsciiNumeric{NumDigits: 3}, Data: types.List{Items: []types.Field{
+					{Name: "tcc", SerDes: types.Ebcdic{NumDigits: 1}},
+					{Name: "se", SerDes: types.TLV{Items: []types.Field{
+						{Name: "21", SerDes: types.Ebcdic{}},
+						{Name: "42", SerDes: types.Ebcdic{}},
+						{Name: "61", SerDes: types.EbcdicNumeric{}},
+					}}},
+				}}},
+				55: types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: types.BerTLV{Items: []types.Field{
+					{Name: "9f26", SerDes: types.Raw{}},
+					{Name: "9f36", SerDes: types.Raw{}},
+				}}},
+				70: types.AsciiNumeric{NumDigits: 3},
+			},
+		}},
+	}}
+}
+
+// Message returns a message of the Spec.
+func Message() serdes.Map {
+	return serdes.Map{
+		"mti": "0100",
+		"2":   "4761739001010010",
+		"3":   "000000",
+		"32":  "123456",
+		"48":  serdes.Map{"tcc": "R", "se": serdes.Map{"21": "01010", "61": "00001"}},
+		"55":  serdes.Map{"9f26": "0123456789abcdef", "9f36": "0001"},
+		"70":  "301",
+	}
+}
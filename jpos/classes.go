@@ -0,0 +1,164 @@
+package jpos
+
+import (
+	"encoding/binary"
+	"regexp"
+	"strings"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// classPattern splits a jPOS field class name, example: IFB_LLHNUM -> encoding B, 2 length digits, binary length, NUM.
+var classPattern = regexp.MustCompile(`^IF(A|B|E)?_(L*)(H?)(NUMERIC|NUM|ECHAR|CHAR|BINARY|BITMAP|AMOUNT)$`)
+
+type class struct {
+	encoding  string
+	digits    int
+	hexLength bool
+	kind      string
+}
+
+// className removes the package of a java class name.
+func className(javaClass string) string {
+	return javaClass[strings.LastIndex(javaClass, ".")+1:]
+}
+
+func parseClass(name string) (class, bool) {
+	match := classPattern.FindStringSubmatch(name)
+	if match == nil {
+		return class{}, false
+	}
+
+	encoding := match[1]
+	if encoding == "" {
+		encoding = "A"
+	}
+
+	return class{encoding: encoding, digits: len(match[2]), hexLength: match[3] != "", kind: match[4]}, true
+}
+
+func prefixDigits(name string) int {
+	parsed, _ := parseClass(name)
+	return parsed.digits
+}
+
+// lengthPrefix returns the serdes of the length prefix of a variable length class.
+func lengthPrefix(name string) (serdes.Serdes, bool) {
+	parsed, ok := parseClass(name)
+	if !ok || parsed.digits == 0 {
+		return nil, false
+	}
+
+	if parsed.hexLength {
+		if parsed.digits == 2 {
+			return types.Byte{}, true
+		}
+		return types.Word{Order: binary.BigEndian}, true
+	}
+
+	switch parsed.encoding {
+	case "A":
+		return types.AsciiNumeric{NumDigits: parsed.digits}, true
+	case "B":
+		return types.Bcd{NumDigits: parsed.digits}, true
+	case "E":
+		return types.EbcdicNumeric{NumDigits: parsed.digits}, true
+	}
+	return nil, false
+}
+
+// translate returns the serdes equivalent to a field class, reporting the classes it cannot translate.
+func translate(path string, field isoField, report *Report) (serdes.Serdes, bool) {
+	name := className(field.Class)
+	parsed, ok := parseClass(name)
+	if !ok {
+		report.add(path, field.Class, "unknown class")
+		return nil, false
+	}
+
+	data, ok := dataSerdes(parsed, field)
+	if !ok {
+		report.add(path, field.Class, "no equivalent type")
+		return nil, false
+	}
+
+	if parsed.digits == 0 {
+		return data, true
+	}
+
+	length, ok := lengthPrefix(name)
+	if !ok {
+		report.add(path, field.Class, "unsupported length prefix")
+		return nil, false
+	}
+
+	return types.VarLength{Desc: types.Desc(field.Name), Length: length, Data: data}, true
+}
+
+func dataSerdes(parsed class, field isoField) (serdes.Serdes, bool) {
+	// variable length data has no fixed size, and takes its description from the VarLength.
+	fixed := parsed.digits == 0
+	numDigits := 0
+	var desc types.Desc
+	if fixed {
+		numDigits = field.Length
+		desc = types.Desc(field.Name)
+	}
+
+	switch parsed.kind {
+	case "NUM", "NUMERIC":
+		switch parsed.encoding {
+		case "A":
+			return types.AsciiNumeric{Desc: desc, NumDigits: numDigits}, true
+		case "B":
+			return types.Bcd{Desc: desc, NumDigits: numDigits, NotPadded: fixed}, true
+		case "E":
+			return types.EbcdicNumeric{Desc: desc, NumDigits: numDigits}, true
+		}
+	case "CHAR":
+		if parsed.encoding == "E" {
+			return types.Ebcdic{Desc: desc, NumDigits: numDigits}, true
+		}
+		return types.Ascii{Desc: desc, NumDigits: numDigits}, true
+	case "ECHAR":
+		return types.Ebcdic{Desc: desc, NumDigits: numDigits}, true
+	case "AMOUNT":
+		if parsed.encoding == "A" && fixed {
+			return types.Ascii{Desc: desc, NumDigits: numDigits}, true
+		}
+	case "BINARY":
+		if parsed.encoding == "B" {
+			return types.Raw{Desc: desc, NumBytes: numDigits}, true
+		}
+
+		// the hex string of IFA_BINARY is the same value types.Raw uses, but the length of the variable length
+		// versions counts bytes instead of chars.
+		if parsed.encoding == "A" && fixed {
+			return types.Ascii{Desc: desc, NumDigits: field.Length * 2}, true
+		}
+	}
+
+	return nil, false
+}
+
+func translateBitmap(field isoField) (types.Bitmap, bool) {
+	parsed, ok := parseClass(className(field.Class))
+	if !ok || parsed.kind != "BITMAP" || parsed.digits > 0 || field.Length <= 0 {
+		return types.Bitmap{}, false
+	}
+
+	numBits := field.Length * 8
+	blockSize := 64
+	if numBits < blockSize {
+		blockSize = numBits
+	}
+
+	switch parsed.encoding {
+	case "A":
+		return types.Bitmap{BlockSize: blockSize, NumBits: numBits, Hex: true}, true
+	case "B":
+		return types.Bitmap{BlockSize: blockSize, NumBits: numBits}, true
+	}
+	return types.Bitmap{}, false
+}
@@ -0,0 +1,197 @@
+// Package jpos imports jPOS GenericPackager XML definitions into serdes trees.
+//
+// The packager is imported as a List with the MTI (field 0) named "mti" followed by an anonymous BitMapped with the
+// remaining fields, field 1 defines its bitmap. Field packagers (isofieldpackager) are imported as a BitMapped when
+// they emit a bitmap and as a List of their fields otherwise, wrapped by the VarLength defined by its class.
+//
+// Fields whose class cannot be translated are left out of the spec and listed in the Report.
+package jpos
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// MTIField is the name of the field where the MTI (jPOS field 0) is imported.
+const MTIField = "mti"
+
+// Error is the error of the package, see serdes.Error.
+type Error = serdes.Error
+
+// Report lists the fields that could not be translated.
+type Report struct {
+	Unsupported []Unsupported `json:"unsupported"`
+}
+
+type Unsupported struct {
+	Path   string `json:"path"`
+	Class  string `json:"class"`
+	Reason string `json:"reason"`
+}
+
+func (report *Report) add(path, class, reason string) {
+	report.Unsupported = append(report.Unsupported, Unsupported{Path: path, Class: class, Reason: reason})
+}
+
+type isoField struct {
+	ID         string     `xml:"id,attr"`
+	Length     int        `xml:"length,attr"`
+	Name       string     `xml:"name,attr"`
+	Class      string     `xml:"class,attr"`
+	EmitBitmap string     `xml:"emitBitmap,attr"`
+	Fields     []isoField `xml:"isofield"`
+	Packagers  []isoField `xml:"isofieldpackager"`
+}
+
+// children returns the fields and the field packagers sorted by id.
+func (field isoField) children() ([]isoField, error) {
+	children := append(append([]isoField{}, field.Fields...), field.Packagers...)
+	ids := make(map[string]bool, len(children))
+	for _, child := range children {
+		if _, err := strconv.Atoi(child.ID); err != nil {
+			return nil, Error{Message: fmt.Sprintf("invalid field id %q", child.ID), Cause: err}
+		}
+
+		if ids[child.ID] {
+			return nil, Error{Message: fmt.Sprintf("duplicated field id %q", child.ID)}
+		}
+		ids[child.ID] = true
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		a, _ := strconv.Atoi(children[i].ID)
+		b, _ := strconv.Atoi(children[j].ID)
+		return a < b
+	})
+	return children, nil
+}
+
+// ImportFile reads a GenericPackager XML file and imports it.
+func ImportFile(filename string) (serdes.Serdes, *Report, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, Error{Message: "error opening packager file", Cause: err}
+	}
+	defer file.Close()
+
+	return Import(file)
+}
+
+// Import reads a GenericPackager XML definition and translates it into a serdes tree.
+func Import(r io.Reader) (serdes.Serdes, *Report, error) {
+	var packager isoField
+	if err := xml.NewDecoder(r).Decode(&packager); err != nil {
+		return nil, nil, Error{Message: "error decoding packager", Cause: err}
+	}
+
+	report := &Report{}
+	children, err := packager.children()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := types.List{}
+	var bitmapField *isoField
+	fields := make([]isoField, 0, len(children))
+	for index, child := range children {
+		switch child.ID {
+		case "0":
+			mti, ok := translate(child.ID, child, report)
+			if ok {
+				list.Items = append(list.Items, types.Field{Name: MTIField, SerDes: mti})
+			}
+		case "1":
+			bitmapField = &children[index]
+		default:
+			fields = append(fields, child)
+		}
+	}
+
+	if bitmapField == nil {
+		return nil, nil, Error{Message: "bitmap (field 1) not found"}
+	}
+
+	bitMapped, err := importBitMapped("", *bitmapField, fields, report)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list.Items = append(list.Items, types.Field{SerDes: bitMapped})
+	return list, report, nil
+}
+
+func importBitMapped(path string, bitmapField isoField, fields []isoField, report *Report) (serdes.Serdes, error) {
+	bitmap, ok := translateBitmap(bitmapField)
+	if !ok {
+		return nil, Error{Message: fmt.Sprintf("unsupported bitmap class %q", bitmapField.Class), Path: serdes.JoinPath(path, bitmapField.ID)}
+	}
+
+	bitMapped := types.BitMapped{Desc: types.Desc(bitmapField.Name), Bitmap: bitmap, Mapping: map[int]serdes.Serdes{}}
+	for _, field := range fields {
+		fieldPath := serdes.JoinPath(path, field.ID)
+		fieldSerdes, ok, err := importField(fieldPath, field, report)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			bitNumber, _ := strconv.Atoi(field.ID)
+			bitMapped.Mapping[bitNumber] = fieldSerdes
+		}
+	}
+
+	return bitMapped, nil
+}
+
+func importField(path string, field isoField, report *Report) (serdes.Serdes, bool, error) {
+	if len(field.Fields) == 0 && len(field.Packagers) == 0 {
+		fieldSerdes, ok := translate(path, field, report)
+		return fieldSerdes, ok, nil
+	}
+
+	children, err := field.children()
+	if err != nil {
+		return nil, false, Error{Message: "invalid field packager", Path: path, Cause: err}
+	}
+
+	var data serdes.Serdes
+	if strings.EqualFold(field.EmitBitmap, "true") && len(children) > 0 {
+		data, err = importBitMapped(path, children[0], children[1:], report)
+		if err != nil {
+			return nil, false, err
+		}
+	} else {
+		list := types.List{Desc: types.Desc(field.Name)}
+		for _, child := range children {
+			childSerdes, ok, err := importField(serdes.JoinPath(path, child.ID), child, report)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if ok {
+				list.Items = append(list.Items, types.Field{Name: child.ID, SerDes: childSerdes})
+			}
+		}
+		data = list
+	}
+
+	class := className(field.Class)
+	length, ok := lengthPrefix(class)
+	if !ok {
+		if prefixDigits(class) > 0 {
+			report.add(path, field.Class, "unsupported length prefix")
+			return nil, false, nil
+		}
+		return data, true, nil
+	}
+
+	return types.VarLength{Desc: types.Desc(field.Name), Length: length, Data: data}, true, nil
+}
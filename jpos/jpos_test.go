@@ -0,0 +1,143 @@
+package jpos_test
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/jpos"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ImportFile_Success(t *testing.T) {
+	spec, report, err := jpos.ImportFile("testdata/iso87ascii.xml")
+	assert.NoError(t, err)
+
+	list, ok := spec.(types.List)
+	assert.True(t, ok)
+	assert.Len(t, list.Items, 2)
+	assert.Equal(t, types.Field{Name: jpos.MTIField, SerDes: types.AsciiNumeric{Desc: "MESSAGE TYPE INDICATOR", NumDigits: 4}}, list.Items[0])
+
+	bitMapped, ok := list.Items[1].SerDes.(types.BitMapped)
+	assert.True(t, ok)
+	assert.Equal(t, types.Desc("BIT MAP"), bitMapped.Desc)
+	assert.Equal(t, types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true}, bitMapped.Bitmap)
+
+	assert.Equal(t, types.VarLength{
+		Desc:   "PAN - PRIMARY ACCOUNT NUMBER",
+		Length: types.AsciiNumeric{NumDigits: 2},
+		Data:   types.AsciiNumeric{},
+	}, bitMapped.Mapping[2])
+	assert.Equal(t, types.AsciiNumeric{Desc: "PROCESSING CODE", NumDigits: 6}, bitMapped.Mapping[3])
+	assert.Equal(t, types.Ascii{Desc: "CARD ACCEPTOR TERMINAL IDENTIFICACION", NumDigits: 8}, bitMapped.Mapping[41])
+	assert.Equal(t, types.Ascii{Desc: "PIN DATA", NumDigits: 16}, bitMapped.Mapping[52])
+	assert.Equal(t, types.Raw{Desc: "MESSAGE SECURITY CODE", NumBytes: 8}, bitMapped.Mapping[96])
+
+	assert.Equal(t, types.VarLength{
+		Desc:   "RESERVED PRIVATE",
+		Length: types.AsciiNumeric{NumDigits: 3},
+		Data: types.List{Desc: "RESERVED PRIVATE", Items: []types.Field{
+			{Name: "1", SerDes: types.AsciiNumeric{Desc: "TERMINAL TYPE", NumDigits: 2}},
+			{Name: "2", SerDes: types.AsciiNumeric{Desc: "ENTRY CAPABILITY", NumDigits: 1}},
+			{Name: "3", SerDes: types.VarLength{Desc: "BATCH NAME", Length: types.AsciiNumeric{NumDigits: 2}, Data: types.Ascii{}}},
+		}},
+	}, bitMapped.Mapping[60])
+
+	field127, ok := bitMapped.Mapping[127].(types.VarLength)
+	assert.True(t, ok)
+	platform, ok := field127.Data.(types.BitMapped)
+	assert.True(t, ok)
+	assert.Equal(t, types.Bitmap{BlockSize: 64, NumBits: 64}, platform.Bitmap)
+	assert.Equal(t, types.Ascii{Desc: "ROUTING INFORMATION", NumDigits: 48}, platform.Mapping[3])
+
+	assert.Equal(t, types.Ascii{Desc: "AMOUNT, TRANSACTION FEE", NumDigits: 9}, bitMapped.Mapping[28])
+	assert.Equal(t, types.VarLength{Desc: "RESERVED ISO USE", Length: types.AsciiNumeric{NumDigits: 4}, Data: types.Ascii{}}, bitMapped.Mapping[110])
+
+	for _, unsupported := range []int{55, 111} {
+		_, ok := bitMapped.Mapping[unsupported]
+		assert.False(t, ok, unsupported)
+	}
+
+	assert.Equal(t, []jpos.Unsupported{
+		{Path: "55", Class: "org.jpos.iso.IFA_LLLBINARY", Reason: "no equivalent type"},
+		{Path: "111", Class: "org.jpos.iso.IFA_FLLCHAR", Reason: "unknown class"},
+		{Path: "127.4", Class: "org.jpos.iso.IFB_AMOUNT", Reason: "no equivalent type"},
+	}, report.Unsupported)
+}
+
+func Test_Import_Serialize_Deserialize(t *testing.T) {
+	spec, _, err := jpos.ImportFile("testdata/iso87ascii.xml")
+	assert.NoError(t, err)
+
+	message := serdes.Map{
+		"mti": "0800",
+		"3":   "990000",
+		"11":  "000001",
+		"41":  "TERM01",
+		"60":  serdes.Map{"1": "01", "2": "5", "3": "BATCH"},
+		"70":  "301",
+	}
+
+	buffer, err := spec.Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, "0800"+"A020000000800010"+"0400000000000000"+"990000"+"000001"+"TERM01  "+"010"+"01"+"5"+"05BATCH"+"301", buffer.String())
+
+	value, err := spec.Deserialize(buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+}
+
+func Test_Import_Length_Prefixes(t *testing.T) {
+	spec, report, err := jpos.Import(strings.NewReader(`<isopackager>
+		<isofield id="0" length="4" name="MTI" class="org.jpos.iso.IFE_NUMERIC"/>
+		<isofield id="1" length="8" name="BITMAP" class="org.jpos.iso.IFB_BITMAP"/>
+		<isofield id="2" length="19" name="PAN" class="org.jpos.iso.IFB_LLNUM"/>
+		<isofield id="3" length="6" name="PROCESSING CODE" class="org.jpos.iso.IFB_NUMERIC"/>
+		<isofield id="35" length="37" name="TRACK 2" class="org.jpos.iso.IFB_LLHNUM"/>
+		<isofield id="43" length="40" name="NAME/LOCATION" class="org.jpos.iso.IFE_CHAR"/>
+		<isofield id="44" length="25" name="ADDITIONAL RESPONSE DATA" class="org.jpos.iso.IFE_LLCHAR"/>
+		<isofield id="55" length="999" name="ICC DATA" class="org.jpos.iso.IFB_LLLHBINARY"/>
+	</isopackager>`))
+	assert.NoError(t, err)
+	assert.Empty(t, report.Unsupported)
+
+	list := spec.(types.List)
+	assert.Equal(t, types.EbcdicNumeric{Desc: "MTI", NumDigits: 4}, list.Items[0].SerDes)
+
+	bitMapped := list.Items[1].SerDes.(types.BitMapped)
+	assert.Equal(t, types.Bitmap{BlockSize: 64, NumBits: 64}, bitMapped.Bitmap)
+	assert.Equal(t, types.VarLength{Desc: "PAN", Length: types.Bcd{NumDigits: 2}, Data: types.Bcd{}}, bitMapped.Mapping[2])
+	assert.Equal(t, types.Bcd{Desc: "PROCESSING CODE", NumDigits: 6, NotPadded: true}, bitMapped.Mapping[3])
+	assert.Equal(t, types.VarLength{Desc: "TRACK 2", Length: types.Byte{}, Data: types.Bcd{}}, bitMapped.Mapping[35])
+	assert.Equal(t, types.Ebcdic{Desc: "NAME/LOCATION", NumDigits: 40}, bitMapped.Mapping[43])
+	assert.Equal(t, types.VarLength{Desc: "ADDITIONAL RESPONSE DATA", Length: types.EbcdicNumeric{NumDigits: 2}, Data: types.Ebcdic{}}, bitMapped.Mapping[44])
+	assert.Equal(t, types.VarLength{Desc: "ICC DATA", Length: types.Word{Order: binary.BigEndian}, Data: types.Raw{}}, bitMapped.Mapping[55])
+}
+
+func Test_Import_Errors(t *testing.T) {
+	_, _, err := jpos.Import(strings.NewReader(`<isopackager`))
+	assert.Error(t, err)
+
+	_, _, err = jpos.Import(strings.NewReader(`<isopackager>
+		<isofield id="0" length="4" name="MTI" class="org.jpos.iso.IFA_NUMERIC"/>
+	</isopackager>`))
+	assert.EqualError(t, err, "bitmap (field 1) not found")
+
+	_, _, err = jpos.Import(strings.NewReader(`<isopackager>
+		<isofield id="1" length="16" name="BITMAP" class="org.jpos.iso.IFA_LLCHAR"/>
+	</isopackager>`))
+	assert.EqualError(t, err, `unsupported bitmap class "org.jpos.iso.IFA_LLCHAR": path: 1.`)
+
+	_, _, err = jpos.Import(strings.NewReader(`<isopackager>
+		<isofield id="1" length="16" name="BITMAP" class="org.jpos.iso.IFA_BITMAP"/>
+		<isofield id="2" length="16" name="PAN" class="org.jpos.iso.IFA_LLNUM"/>
+		<isofield id="2" length="16" name="PAN" class="org.jpos.iso.IFA_LLNUM"/>
+	</isopackager>`))
+	assert.EqualError(t, err, `duplicated field id "2"`)
+
+	_, _, err = jpos.ImportFile("testdata/missing.xml")
+	assert.Error(t, err)
+}
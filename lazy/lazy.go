@@ -0,0 +1,336 @@
+// Package lazy decodes the bitmap of a message and the byte range of each field, the fields are decoded only when they
+// are read. Routing a message usually needs a few fields, like the MTI and the processing code, so the other fields,
+// including their nested TLVs, are never decoded.
+//
+// The fields are the named items of the spec List and the bits of its BitMapped, the fields of anonymous List and
+// BitMapped items are fields of the message, like the keys of the map decoded by the spec. The fields that are not
+// set are forwarded as their raw bytes when the message is encoded again, example:
+//
+//	message, err := lazy.Decode(specs.VisaBaseI(), data)
+//	...
+//	processingCode, err := message.Get("3")
+//	...
+//	err = message.Set("39", "00")
+//	...
+//	response, err := message.Bytes()
+package lazy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// Error is the error of the package, see serdes.Error.
+type Error = serdes.Error
+
+// Message is a message whose fields are decoded when they are read. It is not safe for concurrent use.
+type Message struct {
+	spec   serdes.Serdes
+	data   []byte
+	serdes map[string]serdes.Serdes
+	fields map[string]*field
+}
+
+type field struct {
+	// start and end are the range of the field in data, the fields that were set have no range.
+	start, end int
+	raw        bool
+	decoded    bool
+	value      serdes.Value
+}
+
+// Decode decodes the bitmaps of the message and the byte range of its fields. The spec must be a List or a BitMapped,
+// and data must not be modified while the message is in use.
+func Decode(spec serdes.Serdes, data []byte) (*Message, error) {
+	message := &Message{spec: spec, data: data, serdes: map[string]serdes.Serdes{}, fields: map[string]*field{}}
+	if err := message.indexSerdes(spec); err != nil {
+		return nil, err
+	}
+
+	if _, err := message.index(spec, 0); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// indexSerdes records the serdes of the fields of the spec.
+func (m *Message) indexSerdes(s serdes.Serdes) error {
+	switch value := s.(type) {
+	case types.List:
+		for _, item := range value.Items {
+			if item.Name != "" {
+				m.serdes[item.Name] = item.SerDes
+				continue
+			}
+
+			if err := m.indexSerdes(item.SerDes); err != nil {
+				return err
+			}
+		}
+		return nil
+	case types.BitMapped:
+		for bit, fieldSerdes := range value.Mapping {
+			if fieldSerdes != nil {
+				m.serdes[strconv.Itoa(bit)] = fieldSerdes
+			}
+		}
+		return nil
+	}
+	return Error{Message: fmt.Sprintf("unsupported serdes %s, expected a list or a bitMapped", serdesName(s))}
+}
+
+// index records the range of the fields from off and returns the end of the serdes.
+func (m *Message) index(s serdes.Serdes, off int) (int, error) {
+	switch value := s.(type) {
+	case types.List:
+		for _, item := range value.Items {
+			if off == len(m.data) {
+				break
+			}
+
+			var err error
+			if item.Name == "" {
+				off, err = m.index(item.SerDes, off)
+			} else {
+				off, err = m.record(item.Name, item.SerDes, off)
+			}
+			if err != nil {
+				return off, err
+			}
+		}
+		return off, nil
+	case types.BitMapped:
+		bits, size, err := types.ScanBitmap(value.Bitmap, m.data[off:])
+		if err != nil {
+			return off, Error{Message: "error decoding bitmap", Cause: err}
+		}
+		off += size
+
+		for _, bit := range bits {
+			key := strconv.Itoa(bit)
+			fieldSerdes, exists := value.Mapping[bit]
+			if !exists || fieldSerdes == nil {
+				return off, Error{Message: "field not found", Path: key, Cause: serdes.ErrPathNotFound}
+			}
+
+			if off, err = m.record(key, fieldSerdes, off); err != nil {
+				return off, err
+			}
+		}
+		return off, nil
+	}
+	return off, Error{Message: fmt.Sprintf("unsupported serdes %s, expected a list or a bitMapped", serdesName(s))}
+}
+
+// record records the range of the field that starts in off and returns its end, see types.Scan. The path of the error
+// is the path of the subfield that failed.
+func (m *Message) record(key string, s serdes.Serdes, off int) (int, error) {
+	size, err := types.Scan(s, m.data[off:])
+	if err != nil {
+		path := key
+		var deserializationErr types.DeserializationError
+		if errors.As(err, &deserializationErr) {
+			path = serdes.JoinPath(key, deserializationErr.Path)
+		}
+		return off, Error{Message: "error scanning field", Path: path, Cause: err}
+	}
+
+	m.fields[key] = &field{start: off, end: off + size, raw: true}
+	return off + size, nil
+}
+
+// Spec returns the spec of the message.
+func (m *Message) Spec() serdes.Serdes {
+	return m.spec
+}
+
+// Has returns true when the message has the field.
+func (m *Message) Has(key string) bool {
+	_, exists := m.fields[key]
+	return exists
+}
+
+// Keys returns the keys of the fields of the message in encoding order.
+func (m *Message) Keys() []string {
+	return m.keys(m.spec, nil)
+}
+
+func (m *Message) keys(s serdes.Serdes, keys []string) []string {
+	switch value := s.(type) {
+	case types.List:
+		for _, item := range value.Items {
+			if item.Name == "" {
+				keys = m.keys(item.SerDes, keys)
+			} else if m.Has(item.Name) {
+				keys = append(keys, item.Name)
+			}
+		}
+	case types.BitMapped:
+		for _, bit := range sortedBits(value) {
+			if key := strconv.Itoa(bit); m.Has(key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// Range returns the byte range of the field in the message data, fields that were set have no range.
+func (m *Message) Range(key string) (start, end int, ok bool) {
+	f, exists := m.fields[key]
+	if !exists || !f.raw {
+		return 0, 0, false
+	}
+	return f.start, f.end, true
+}
+
+// Raw returns the encoded field from the message data without decoding it, fields that were set have no raw bytes.
+func (m *Message) Raw(key string) ([]byte, bool) {
+	start, end, ok := m.Range(key)
+	if !ok {
+		return nil, false
+	}
+	return m.data[start:end], true
+}
+
+// Get decodes the field, the value is kept so the field is decoded once.
+func (m *Message) Get(key string) (serdes.Value, error) {
+	f, exists := m.fields[key]
+	if !exists {
+		return nil, Error{Message: "field not found", Path: key, Cause: serdes.ErrPathNotFound}
+	}
+
+	if f.decoded {
+		return f.value, nil
+	}
+
+	value, err := m.serdes[key].Deserialize(bytes.NewBuffer(m.data[f.start:f.end]))
+	if err != nil {
+		return nil, Error{Message: "error decoding field", Path: key, Cause: err}
+	}
+
+	f.value, f.decoded = value, true
+	return value, nil
+}
+
+// Set sets the value of the field, the field is encoded with the value when the message is encoded.
+func (m *Message) Set(key string, value serdes.Value) error {
+	if _, exists := m.serdes[key]; !exists {
+		return Error{Message: "field not found in spec", Path: key, Cause: serdes.ErrPathNotFound}
+	}
+
+	m.fields[key] = &field{decoded: true, value: value}
+	return nil
+}
+
+// Delete removes the field from the message.
+func (m *Message) Delete(key string) {
+	delete(m.fields, key)
+}
+
+// Value decodes all the fields of the message.
+func (m *Message) Value() (serdes.Map, error) {
+	value := serdes.Map{}
+	for _, key := range m.Keys() {
+		fieldValue, err := m.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		value[key] = fieldValue
+	}
+	return value, nil
+}
+
+// Bytes encodes the message, see Append.
+func (m *Message) Bytes() ([]byte, error) {
+	return m.Append(nil)
+}
+
+// Append appends the encoded message to dst. The fields that were not set are copied from the message data without
+// being decoded or encoded, and the bitmaps are encoded from the fields of the message. On error dst is returned
+// unchanged.
+func (m *Message) Append(dst []byte) ([]byte, error) {
+	return m.append(dst, m.spec)
+}
+
+func (m *Message) append(dst []byte, s serdes.Serdes) ([]byte, error) {
+	start := len(dst)
+	switch value := s.(type) {
+	case types.List:
+		for _, item := range value.Items {
+			var err error
+			if item.Name == "" {
+				dst, err = m.append(dst, item.SerDes)
+			} else if m.Has(item.Name) {
+				dst, err = m.appendField(dst, item.Name)
+			}
+			if err != nil {
+				return dst[:start], err
+			}
+		}
+		return dst, nil
+	case types.BitMapped:
+		keys := m.keys(value, nil)
+		if len(keys) == 0 {
+			return dst, nil
+		}
+
+		lastBit, _ := strconv.Atoi(keys[len(keys)-1])
+		bitmap := make([]byte, (lastBit+7)/8)
+		for _, key := range keys {
+			bitIndex, _ := strconv.Atoi(key)
+			bitIndex--
+			bitmap[bitIndex/8] |= 0x80 >> uint(bitIndex%8)
+		}
+
+		encoded, err := serdes.AppendSerialize(dst, value.Bitmap, bitmap)
+		if err != nil {
+			return dst, Error{Message: "error encoding bitmap", Cause: err}
+		}
+
+		for _, key := range keys {
+			if encoded, err = m.appendField(encoded, key); err != nil {
+				return dst, err
+			}
+		}
+		return encoded, nil
+	}
+	return dst, Error{Message: fmt.Sprintf("unsupported serdes %s, expected a list or a bitMapped", serdesName(s))}
+}
+
+func (m *Message) appendField(dst []byte, key string) ([]byte, error) {
+	f := m.fields[key]
+	if f.raw {
+		return append(dst, m.data[f.start:f.end]...), nil
+	}
+
+	encoded, err := serdes.AppendSerialize(dst, m.serdes[key], f.value)
+	if err != nil {
+		return dst, Error{Message: "error encoding field", Path: key, Cause: err}
+	}
+	return encoded, nil
+}
+
+func sortedBits(bitMapped types.BitMapped) []int {
+	bits := make([]int, 0, len(bitMapped.Mapping))
+	for bit, fieldSerdes := range bitMapped.Mapping {
+		if fieldSerdes != nil {
+			bits = append(bits, bit)
+		}
+	}
+	sort.Ints(bits)
+	return bits
+}
+
+func serdesName(s serdes.Serdes) string {
+	if s == nil {
+		return "nil"
+	}
+	return s.Name()
+}
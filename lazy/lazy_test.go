@@ -0,0 +1,127 @@
+package lazy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/internal/testspec"
+	"github.com/mercadolibre/go-iso8583/lazy"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Decode(t *testing.T) {
+	data, err := testspec.Spec().Serialize(testspec.Message())
+	assert.NoError(t, err)
+
+	decoded, err := lazy.Decode(testspec.Spec(), data.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mti", "2", "3", "32", "48", "55", "70"}, decoded.Keys())
+	assert.True(t, decoded.Has("3"))
+	assert.False(t, decoded.Has("39"))
+
+	start, end, ok := decoded.Range("mti")
+	assert.True(t, ok)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 4, end)
+
+	raw, ok := decoded.Raw("32")
+	assert.True(t, ok)
+	assert.Equal(t, "06123456", string(raw))
+
+	value, err := decoded.Get("3")
+	assert.NoError(t, err)
+	assert.Equal(t, "000000", value)
+
+	all, err := decoded.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, testspec.Message(), all)
+
+	_, err = decoded.Get("39")
+	assert.EqualError(t, err, "field not found: path: 39. -> path not found")
+	assert.True(t, errors.Is(err, serdes.ErrPathNotFound))
+}
+
+func Test_Decode_Only_On_Access(t *testing.T) {
+	data, err := testspec.Spec().Serialize(testspec.Message())
+	assert.NoError(t, err)
+
+	// the length of the tag 21 of the field 48 is corrupted, only reading the field fails.
+	bytes := data.Bytes()
+	start, _, _ := mustDecode(t, bytes).Range("48")
+	bytes[start+6] = 0xf9
+
+	decoded := mustDecode(t, bytes)
+	value, err := decoded.Get("2")
+	assert.NoError(t, err)
+	assert.Equal(t, "4761739001010010", value)
+
+	_, err = decoded.Get("48")
+	assert.Error(t, err)
+
+	forwarded, err := decoded.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, bytes, forwarded)
+}
+
+func Test_Message_Set(t *testing.T) {
+	data, err := testspec.Spec().Serialize(testspec.Message())
+	assert.NoError(t, err)
+
+	decoded := mustDecode(t, data.Bytes())
+	assert.NoError(t, decoded.Set("mti", "0110"))
+	assert.NoError(t, decoded.Set("39", "00"))
+	decoded.Delete("2")
+	decoded.Delete("70")
+
+	_, ok := decoded.Raw("39")
+	assert.False(t, ok)
+
+	err = decoded.Set("99", "00")
+	assert.EqualError(t, err, "field not found in spec: path: 99. -> path not found")
+
+	response := testspec.Message()
+	response["mti"] = "0110"
+	response["39"] = "00"
+	delete(response, "2")
+	delete(response, "70")
+	expected, err := testspec.Spec().Serialize(response)
+	assert.NoError(t, err)
+
+	encoded, err := decoded.Append([]byte("prefix"))
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte("prefix"), expected.Bytes()...), encoded)
+
+	assert.NoError(t, decoded.Set("3", "1234567"))
+	_, err = decoded.Bytes()
+	assert.EqualError(t, err, "error encoding field: path: 3. -> value too long: serializer: ascii_numeric. value type: string.")
+
+	encoded, err = decoded.Append([]byte("prefix"))
+	assert.Error(t, err)
+	assert.Equal(t, []byte("prefix"), encoded)
+}
+
+func Test_Decode_Errors(t *testing.T) {
+	data, err := testspec.Spec().Serialize(testspec.Message())
+	assert.NoError(t, err)
+
+	_, err = lazy.Decode(testspec.Spec(), data.Bytes()[:data.Len()-1])
+	assert.EqualError(t, err, "error scanning field: path: 70. -> data does not has bytes enough: deserializer: ascii_numeric. *data remaning: 2.")
+	assert.True(t, errors.Is(err, types.ErrShortBuffer))
+
+	_, err = lazy.Decode(types.Ascii{}, data.Bytes())
+	assert.EqualError(t, err, "unsupported serdes ascii, expected a list or a bitMapped")
+
+	withoutField := testspec.Spec()
+	delete(withoutField.Items[1].SerDes.(types.BitMapped).Mapping, 32)
+	_, err = lazy.Decode(withoutField, data.Bytes())
+	assert.EqualError(t, err, "field not found: path: 32. -> path not found")
+}
+
+func mustDecode(t *testing.T, data []byte) *lazy.Message {
+	decoded, err := lazy.Decode(testspec.Spec(), data)
+	assert.NoError(t, err)
+	return decoded
+}
@@ -0,0 +1,283 @@
+// Package lint reports the definitions of a serdes tree that would only fail when a message is processed, like a
+// bitmap block size that is not a multiple of 8 or a length prefix that cannot represent the length of its data.
+//
+// The problems are reported with the paths of the values of the fields, the same paths used by the overlay and diff
+// packages, example:
+//
+//	if err := lint.Validate(spec); err != nil {
+//		log.Fatal(err)
+//	}
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// Checks reported by the linter.
+const (
+	BitmapBlockSize  = "bitmap_block_size"
+	BitmapNumBits    = "bitmap_num_bits"
+	ContinuationBit  = "continuation_bit"
+	BitOutOfRange    = "bit_out_of_range"
+	AnonymousItems   = "anonymous_items"
+	AnonymousValue   = "anonymous_value"
+	DuplicatedName   = "duplicated_name"
+	UnnamedTag       = "unnamed_tag"
+	VariablePrefix   = "variable_prefix"
+	LengthCapacity   = "length_capacity"
+	TagValueCapacity = "tag_value_capacity"
+)
+
+// Issue is a problem found in the definition of the field of a path.
+type Issue struct {
+	Path    string `json:"path"`
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+func (issue Issue) String() string {
+	path := issue.Path
+	if path == "" {
+		path = "(root)"
+	}
+	return fmt.Sprintf("%s: %s: %s", path, issue.Check, issue.Message)
+}
+
+// Error is returned by Validate with all the issues of the spec.
+type Error struct {
+	Issues []Issue `json:"issues"`
+}
+
+func (err Error) Error() string {
+	lines := make([]string, 0, len(err.Issues))
+	for _, issue := range err.Issues {
+		lines = append(lines, issue.String())
+	}
+	return fmt.Sprintf("invalid spec, %d issues found: %s", len(err.Issues), strings.Join(lines, "; "))
+}
+
+// Validate returns an Error with the issues of the spec, nil when there are none.
+func Validate(s serdes.Serdes) error {
+	if issues := Check(s); len(issues) > 0 {
+		return Error{Issues: issues}
+	}
+	return nil
+}
+
+// Check returns the issues of the spec in encoding order.
+func Check(s serdes.Serdes) []Issue {
+	l := &linter{}
+	_ = types.Walk(s, func(path string, child types.Child, _ serdes.Serdes) error {
+		l.check(path, child.SerDes)
+		return nil
+	})
+	return l.issues
+}
+
+type linter struct {
+	issues []Issue
+}
+
+func (l *linter) add(path, check, format string, args ...interface{}) {
+	l.issues = append(l.issues, Issue{Path: path, Check: check, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) check(path string, s serdes.Serdes) {
+	switch value := s.(type) {
+	case types.Bitmap:
+		l.bitmap(path, value)
+	case types.BitMapped:
+		l.bitMapped(path, value)
+	case types.List:
+		l.list(path, value)
+	case types.TLV:
+		l.names(path, value.Items)
+		l.tlv(path, value)
+	case types.BerTLV:
+		l.names(path, value.Items)
+	case types.VarLength:
+		l.varLength(path, value)
+	}
+}
+
+func (l *linter) bitmap(path string, bitmap types.Bitmap) {
+	if bitmap.BlockSize <= 0 || bitmap.BlockSize%8 != 0 {
+		l.add(path, BitmapBlockSize, "block size %d is not a positive multiple of 8", bitmap.BlockSize)
+		return
+	}
+
+	if bitmap.NumBits <= 0 || bitmap.NumBits%bitmap.BlockSize != 0 {
+		l.add(path, BitmapNumBits, "number of bits %d is not a positive multiple of the block size %d",
+			bitmap.NumBits, bitmap.BlockSize)
+	}
+}
+
+func (l *linter) bitMapped(path string, bitMapped types.BitMapped) {
+	bitmap := bitMapped.Bitmap
+	if bitmap.BlockSize <= 0 || bitmap.NumBits <= 0 {
+		return
+	}
+
+	bits := make([]int, 0, len(bitMapped.Mapping))
+	for bit := range bitMapped.Mapping {
+		bits = append(bits, bit)
+	}
+	sort.Ints(bits)
+
+	numBlocks := bitmap.NumBits / bitmap.BlockSize
+	for _, bit := range bits {
+		bitPath := serdes.JoinPath(path, strconv.Itoa(bit))
+		switch {
+		case bit < 1 || bit > bitmap.NumBits:
+			l.add(bitPath, BitOutOfRange, "bit %d is out of the bitmap range 1-%d", bit, bitmap.NumBits)
+		case (bit-1)%bitmap.BlockSize == 0 && (bit-1)/bitmap.BlockSize < numBlocks-1:
+			l.add(bitPath, ContinuationBit, "bit %d flags the next bitmap block and cannot have a field", bit)
+		}
+	}
+}
+
+// list checks the anonymous items, their values are merged into the value of the list so they must be maps and there
+// can be only one of them.
+func (l *linter) list(path string, list types.List) {
+	l.names(path, list.Items)
+
+	anonymous := 0
+	for index, item := range list.Items {
+		if item.Name != "" {
+			continue
+		}
+
+		anonymous++
+		if anonymous == 2 {
+			l.add(path, AnonymousItems, "item %d is the second anonymous item of the list", index)
+		}
+
+		if !mapValue(item.SerDes) {
+			l.add(path, AnonymousValue, "anonymous item %d (%s) does not decode a map", index, item.SerDes.Name())
+		}
+	}
+}
+
+func (l *linter) names(path string, items []types.Field) {
+	names := make(map[string]bool, len(items))
+	for index, item := range items {
+		if item.Name == "" {
+			continue
+		}
+
+		if names[item.Name] {
+			l.add(serdes.JoinPath(path, item.Name), DuplicatedName, "item %d duplicates the name %q", index, item.Name)
+		}
+		names[item.Name] = true
+	}
+}
+
+func (l *linter) tlv(path string, tlv types.TLV) {
+	sizeLen := tlv.SizeLen
+	if sizeLen == 0 {
+		sizeLen = 2
+	}
+
+	for index, item := range tlv.Items {
+		if item.Name == "" {
+			l.add(path, UnnamedTag, "item %d has no tag", index)
+			continue
+		}
+
+		if size, fixed := fixedSize(item.SerDes); fixed && size >= pow10(sizeLen) {
+			l.add(serdes.JoinPath(path, item.Name), TagValueCapacity, "value size %d does not fit in %d length digits",
+				size, sizeLen)
+		}
+	}
+}
+
+// varLength checks that the length prefix has a fixed size and can represent the length of the data, the length of
+// Bcd data is counted in digits.
+func (l *linter) varLength(path string, varLen types.VarLength) {
+	if varLen.Length == nil || varLen.Data == nil {
+		return
+	}
+
+	if _, fixed := fixedSize(varLen.Length); !fixed {
+		l.add(path, VariablePrefix, "length prefix %s has no fixed size", varLen.Length.Name())
+		return
+	}
+
+	maxLength, ok := capacity(varLen.Length)
+	if !ok {
+		return
+	}
+
+	length, fixed := fixedSize(varLen.Data)
+	if bcd, ok := varLen.Data.(types.Bcd); ok {
+		length, fixed = bcd.NumDigits, bcd.NumDigits > 0
+	}
+
+	if fixed && length > maxLength {
+		l.add(path, LengthCapacity, "length prefix %s represents up to %d, the data length is %d",
+			varLen.Length.Name(), maxLength, length)
+	}
+}
+
+// capacity returns the max length that a length prefix can represent.
+func capacity(s serdes.Serdes) (int, bool) {
+	switch value := s.(type) {
+	case types.Byte:
+		return 0xff, true
+	case types.Word:
+		return 0xffff, true
+	case types.Bcd:
+		return digitsCapacity(value.NumDigits)
+	case types.AsciiNumeric:
+		return digitsCapacity(value.NumDigits)
+	case types.EbcdicNumeric:
+		return digitsCapacity(value.NumDigits)
+	case types.Ascii:
+		return digitsCapacity(value.NumDigits)
+	case types.Ebcdic:
+		return digitsCapacity(value.NumDigits)
+	}
+	return 0, false
+}
+
+func digitsCapacity(numDigits int) (int, bool) {
+	// lengths above 9 digits are not limited by the prefix.
+	if numDigits <= 0 || numDigits > 9 {
+		return 0, false
+	}
+	return pow10(numDigits) - 1, true
+}
+
+func fixedSize(s serdes.Serdes) (int, bool) {
+	introspectable, ok := s.(types.Introspectable)
+	if !ok {
+		return 0, false
+	}
+	return introspectable.FixedSize()
+}
+
+// mapValue returns if the serdes decodes a map, unknown serdes are assumed to do it.
+func mapValue(s serdes.Serdes) bool {
+	switch value := s.(type) {
+	case types.VarLength:
+		return mapValue(value.Data)
+	case types.List, types.BitMapped, types.TLV, types.BerTLV:
+		return true
+	}
+	_, introspectable := s.(types.Introspectable)
+	return !introspectable
+}
+
+func pow10(exp int) int {
+	result := 1
+	for i := 0; i < exp; i++ {
+		result *= 10
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package lint_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/lint"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Check(t *testing.T) {
+	tests := []struct {
+		name   string
+		serdes serdes.Serdes
+		issues []lint.Issue
+	}{
+		{
+			name: "block size",
+			serdes: types.BitMapped{
+				Bitmap:  types.Bitmap{BlockSize: 60, NumBits: 120},
+				Mapping: map[int]serdes.Serdes{2: types.Byte{}},
+			},
+			issues: []lint.Issue{
+				{Path: "", Check: lint.BitmapBlockSize, Message: "block size 60 is not a positive multiple of 8"},
+			},
+		},
+		{
+			name: "num bits",
+			serdes: types.BitMapped{
+				Bitmap:  types.Bitmap{BlockSize: 64, NumBits: 100},
+				Mapping: map[int]serdes.Serdes{2: types.Byte{}},
+			},
+			issues: []lint.Issue{
+				{Path: "", Check: lint.BitmapNumBits, Message: "number of bits 100 is not a positive multiple of the block size 64"},
+			},
+		},
+		{
+			name: "bits",
+			serdes: types.BitMapped{
+				Bitmap:  types.Bitmap{BlockSize: 64, NumBits: 192},
+				Mapping: map[int]serdes.Serdes{0: types.Byte{}, 1: types.Byte{}, 65: types.Byte{}, 129: types.Byte{}, 193: types.Byte{}},
+			},
+			issues: []lint.Issue{
+				{Path: "0", Check: lint.BitOutOfRange, Message: "bit 0 is out of the bitmap range 1-192"},
+				{Path: "1", Check: lint.ContinuationBit, Message: "bit 1 flags the next bitmap block and cannot have a field"},
+				{Path: "65", Check: lint.ContinuationBit, Message: "bit 65 flags the next bitmap block and cannot have a field"},
+				{Path: "193", Check: lint.BitOutOfRange, Message: "bit 193 is out of the bitmap range 1-192"},
+			},
+		},
+		{
+			name: "anonymous items",
+			serdes: types.List{Items: []types.Field{
+				{Name: "mti", SerDes: types.Bcd{NumDigits: 4}},
+				{SerDes: types.BitMapped{Bitmap: types.Bitmap{BlockSize: 64, NumBits: 64}}},
+				{SerDes: types.Byte{}},
+				{Name: "mti", SerDes: types.Byte{}},
+			}},
+			issues: []lint.Issue{
+				{Path: "mti", Check: lint.DuplicatedName, Message: "item 3 duplicates the name \"mti\""},
+				{Path: "", Check: lint.AnonymousItems, Message: "item 2 is the second anonymous item of the list"},
+				{Path: "", Check: lint.AnonymousValue, Message: "anonymous item 2 (byte) does not decode a map"},
+			},
+		},
+		{
+			name: "length capacity",
+			serdes: types.List{Items: []types.Field{
+				{Name: "a", SerDes: types.VarLength{Length: types.Byte{}, Data: types.Raw{NumBytes: 300}}},
+				{Name: "b", SerDes: types.VarLength{Length: types.AsciiNumeric{NumDigits: 2}, Data: types.Bcd{NumDigits: 120}}},
+				{Name: "c", SerDes: types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: types.Raw{NumBytes: 300}}},
+				{Name: "d", SerDes: types.VarLength{Length: types.AsciiNumeric{}, Data: types.Ascii{}}},
+				{Name: "e", SerDes: types.VarLength{Length: types.Bcd{NumDigits: 2}, Data: types.Ascii{}}},
+			}},
+			issues: []lint.Issue{
+				{Path: "a", Check: lint.LengthCapacity, Message: "length prefix byte represents up to 255, the data length is 300"},
+				{Path: "b", Check: lint.LengthCapacity, Message: "length prefix ascii_numeric represents up to 99, the data length is 120"},
+				{Path: "d", Check: lint.VariablePrefix, Message: "length prefix ascii_numeric has no fixed size"},
+			},
+		},
+		{
+			name: "tlv",
+			serdes: types.TLV{Items: []types.Field{
+				{Name: "01", SerDes: types.Ebcdic{NumDigits: 100}},
+				{SerDes: types.Ebcdic{}},
+			}},
+			issues: []lint.Issue{
+				{Path: "01", Check: lint.TagValueCapacity, Message: "value size 100 does not fit in 2 length digits"},
+				{Path: "", Check: lint.UnnamedTag, Message: "item 1 has no tag"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.issues, lint.Check(tt.serdes))
+		})
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	err := lint.Validate(types.BitMapped{Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128}, Mapping: map[int]serdes.Serdes{1: types.Byte{}}})
+	assert.EqualError(t, err, "invalid spec, 1 issues found: 1: continuation_bit: bit 1 flags the next bitmap block and cannot have a field")
+
+	for _, s := range []serdes.Serdes{
+		specs.ISO87ASCII(specs.HexBitmap),
+		specs.ISO93ASCII(specs.BinaryBitmap),
+		specs.ISO2003ASCII(specs.HexBitmap),
+		specs.VisaBaseI(),
+		specs.Mastercard(),
+		specs.AmexGCAG(),
+		specs.Discover(specs.HexBitmap),
+	} {
+		assert.NoError(t, lint.Validate(s))
+	}
+}
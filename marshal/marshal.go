@@ -0,0 +1,326 @@
+// Package marshal converts between Go structs and the serdes.Map values consumed by the types package.
+//
+// Struct fields are mapped with the iso8583 tag, example:
+//
+//	type Message struct {
+//		MTI    string  `iso8583:"mti"`
+//		PAN    string  `iso8583:"2"`
+//		Amount int64   `iso8583:"4"`
+//		Track2 *string `iso8583:"35"`
+//		POS    *POS    `iso8583:"60"`
+//	}
+//
+// Strings are copied as they are, integers are converted to their decimal representation, []byte are converted to
+// hex strings (as types.Raw expects) and structs are converted to nested maps, so a struct can describe the subfields
+// of a BitMapped, List, TLV or BerTLV.
+//
+// Pointer fields are optional: nil pointers are not added to the map and are left nil when the key is missing.
+// The omitempty option skips zero values, and "-" ignores the field. Embedded structs without tag are inlined in the
+// parent map, the same way types.List inlines its anonymous fields.
+package marshal
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+)
+
+const tagName = "iso8583"
+
+var (
+	mapType   = reflect.TypeOf(serdes.Map{})
+	bytesType = reflect.TypeOf([]byte{})
+
+	fieldsCache sync.Map // map[reflect.Type][]field
+)
+
+// Error is the error of the package, see serdes.Error.
+type Error = serdes.Error
+
+type field struct {
+	key       string
+	index     []int
+	omitEmpty bool
+}
+
+// Marshal converts a struct, or a pointer to struct, into a serdes.Map.
+func Marshal(v interface{}) (serdes.Map, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, Error{Message: "nil value"}
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, Error{Message: fmt.Sprintf("invalid value [%T], expected a struct", v)}
+	}
+
+	return marshalStruct("", value)
+}
+
+// Unmarshal fills the struct pointed by v with the values of m.
+func Unmarshal(m serdes.Map, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return Error{Message: fmt.Sprintf("invalid value [%T], expected a non nil pointer to struct", v)}
+	}
+
+	value = value.Elem()
+	if value.Kind() != reflect.Struct {
+		return Error{Message: fmt.Sprintf("invalid value [%T], expected a non nil pointer to struct", v)}
+	}
+
+	return unmarshalStruct("", m, value)
+}
+
+func marshalStruct(path string, value reflect.Value) (serdes.Map, error) {
+	out := serdes.Map{}
+	for _, f := range cachedFields(value.Type()) {
+		fieldValue, ok := fieldByIndex(value, f.index)
+		if !ok {
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Ptr || fieldValue.Kind() == reflect.Interface || fieldValue.Kind() == reflect.Map {
+			if fieldValue.IsNil() {
+				continue
+			}
+		}
+
+		if f.omitEmpty && fieldValue.IsZero() {
+			continue
+		}
+
+		fieldPath := serdes.JoinPath(path, f.key)
+		item, err := marshalValue(fieldPath, fieldValue)
+		if err != nil {
+			return nil, err
+		}
+
+		out[f.key] = item
+	}
+
+	return out, nil
+}
+
+func marshalValue(path string, value reflect.Value) (serdes.Value, error) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Type() == bytesType {
+		return hex.EncodeToString(value.Bytes()), nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Struct:
+		return marshalStruct(path, value)
+	case reflect.Map:
+		if value.Type().ConvertibleTo(mapType) {
+			return value.Convert(mapType).Interface(), nil
+		}
+	}
+
+	return nil, Error{Message: fmt.Sprintf("unsupported type %s", value.Type()), Path: path}
+}
+
+func unmarshalStruct(path string, m serdes.Map, value reflect.Value) error {
+	for _, f := range cachedFields(value.Type()) {
+		item, exists := m[f.key]
+		if !exists || item == nil {
+			continue
+		}
+
+		fieldValue := allocFieldByIndex(value, f.index)
+		if err := unmarshalValue(serdes.JoinPath(path, f.key), item, fieldValue); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmarshalValue(path string, item serdes.Value, value reflect.Value) error {
+	if value.Kind() == reflect.Ptr {
+		target := reflect.New(value.Type().Elem())
+		if err := unmarshalValue(path, item, target.Elem()); err != nil {
+			return err
+		}
+
+		value.Set(target)
+		return nil
+	}
+
+	if value.Kind() == reflect.Interface && value.NumMethod() == 0 {
+		value.Set(reflect.ValueOf(item))
+		return nil
+	}
+
+	if value.Kind() == reflect.Struct {
+		mapItem, ok := item.(serdes.Map)
+		if !ok {
+			return Error{Message: fmt.Sprintf("invalid value [%T], expected: %T", item, serdes.Map{}), Path: path}
+		}
+		return unmarshalStruct(path, mapItem, value)
+	}
+
+	if value.Kind() == reflect.Map {
+		mapItem, ok := item.(serdes.Map)
+		if !ok || !mapType.ConvertibleTo(value.Type()) {
+			return Error{Message: fmt.Sprintf("invalid value [%T] for %s", item, value.Type()), Path: path}
+		}
+		value.Set(reflect.ValueOf(mapItem).Convert(value.Type()))
+		return nil
+	}
+
+	str, ok := item.(string)
+	if !ok {
+		return Error{Message: fmt.Sprintf("invalid value [%T], expected: string", item), Path: path}
+	}
+
+	if value.Type() == bytesType {
+		raw, err := hex.DecodeString(str)
+		if err != nil {
+			return Error{Message: "invalid hex string", Path: path, Cause: err}
+		}
+		value.SetBytes(raw)
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(str)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := parseNumber(str, func(s string) (interface{}, error) {
+			return strconv.ParseInt(s, 10, value.Type().Bits())
+		})
+		if err != nil {
+			return Error{Message: "invalid integer", Path: path, Cause: err}
+		}
+		value.SetInt(n.(int64))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := parseNumber(str, func(s string) (interface{}, error) {
+			return strconv.ParseUint(s, 10, value.Type().Bits())
+		})
+		if err != nil {
+			return Error{Message: "invalid unsigned integer", Path: path, Cause: err}
+		}
+		value.SetUint(n.(uint64))
+		return nil
+	}
+
+	return Error{Message: fmt.Sprintf("unsupported type %s", value.Type()), Path: path}
+}
+
+// parseNumber parses a decimal string, an empty string is zero since Bcd trims the leading zeros of the values.
+func parseNumber(str string, parse func(string) (interface{}, error)) (interface{}, error) {
+	if str == "" {
+		str = "0"
+	}
+	return parse(str)
+}
+
+func cachedFields(t reflect.Type) []field {
+	if cached, ok := fieldsCache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	fields := typeFields(t, nil)
+	fieldsCache.Store(t, fields)
+	return fields
+}
+
+func typeFields(t reflect.Type, parentIndex []int) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag, hasTag := structField.Tag.Lookup(tagName)
+		if tag == "-" {
+			continue
+		}
+
+		index := make([]int, len(parentIndex)+1)
+		copy(index, parentIndex)
+		index[len(parentIndex)] = i
+
+		if !hasTag {
+			fieldType := structField.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+
+			if structField.Anonymous && fieldType.Kind() == reflect.Struct {
+				fields = append(fields, typeFields(fieldType, index)...)
+			}
+			continue
+		}
+
+		if structField.PkgPath != "" {
+			continue
+		}
+
+		key, options := parseTag(tag)
+		if key == "" {
+			continue
+		}
+
+		fields = append(fields, field{key: key, index: index, omitEmpty: options["omitempty"]})
+	}
+
+	return fields
+}
+
+func parseTag(tag string) (string, map[string]bool) {
+	parts := strings.Split(tag, ",")
+	options := map[string]bool{}
+	for _, option := range parts[1:] {
+		options[strings.TrimSpace(option)] = true
+	}
+	return strings.TrimSpace(parts[0]), options
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but reports false when a nil embedded pointer is found.
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		value = value.Field(x)
+	}
+	return value, true
+}
+
+// allocFieldByIndex is like reflect.Value.FieldByIndex, but allocates the nil embedded pointers found.
+func allocFieldByIndex(value reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(x)
+	}
+	return value
+}
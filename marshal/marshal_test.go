@@ -0,0 +1,166 @@
+package marshal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/marshal"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type header struct {
+	MTI string `iso8583:"mti"`
+}
+
+type posData struct {
+	TerminalType string `iso8583:"1"`
+	EntryMode    string `iso8583:"2"`
+}
+
+type authorization struct {
+	header
+	PAN       string            `iso8583:"2"`
+	Amount    int64             `iso8583:"4"`
+	STAN      uint32            `iso8583:"11"`
+	Track2    *string           `iso8583:"35"`
+	PinBlock  []byte            `iso8583:"52,omitempty"`
+	POS       *posData          `iso8583:"60"`
+	Private   map[string]string `iso8583:"-"`
+	Reserved  serdes.Map        `iso8583:"63"`
+	notMapped string
+}
+
+func Test_Marshal_Success(t *testing.T) {
+	track2 := "4111111111111111D2512"
+	value := authorization{
+		header:   header{MTI: "0100"},
+		PAN:      "4111111111111111",
+		Amount:   1500,
+		STAN:     42,
+		Track2:   &track2,
+		POS:      &posData{TerminalType: "0", EntryMode: "5"},
+		Reserved: serdes.Map{"1": "0002"},
+	}
+
+	got, err := marshal.Marshal(&value)
+	assert.NoError(t, err)
+
+	expected := serdes.Map{
+		"mti": "0100",
+		"2":   "4111111111111111",
+		"4":   "1500",
+		"11":  "42",
+		"35":  "4111111111111111D2512",
+		"60":  serdes.Map{"1": "0", "2": "5"},
+		"63":  serdes.Map{"1": "0002"},
+	}
+	assert.Equal(t, expected, got)
+}
+
+func Test_Marshal_Errors(t *testing.T) {
+	_, err := marshal.Marshal("invalid type")
+	assert.Error(t, err)
+
+	_, err = marshal.Marshal((*authorization)(nil))
+	assert.Error(t, err)
+
+	type unsupported struct {
+		Rate float64 `iso8583:"9"`
+	}
+	_, err = marshal.Marshal(unsupported{Rate: 1.5})
+	assert.EqualError(t, err, "unsupported type float64: path: 9.")
+}
+
+func Test_Unmarshal_Success(t *testing.T) {
+	in := serdes.Map{
+		"mti": "0110",
+		"2":   "4111111111111111",
+		"4":   "",
+		"11":  "000042",
+		"52":  "0102030405060708",
+		"60":  serdes.Map{"1": "0", "2": "5"},
+		"63":  serdes.Map{"1": "0002"},
+	}
+
+	var got authorization
+	assert.NoError(t, marshal.Unmarshal(in, &got))
+
+	expected := authorization{
+		header:   header{MTI: "0110"},
+		PAN:      "4111111111111111",
+		Amount:   0,
+		STAN:     42,
+		PinBlock: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		POS:      &posData{TerminalType: "0", EntryMode: "5"},
+		Reserved: serdes.Map{"1": "0002"},
+	}
+	assert.Equal(t, expected, got)
+	assert.Nil(t, got.Track2)
+}
+
+func Test_Unmarshal_Errors(t *testing.T) {
+	var msg authorization
+	assert.Error(t, marshal.Unmarshal(serdes.Map{}, msg))
+	assert.Error(t, marshal.Unmarshal(serdes.Map{}, (*authorization)(nil)))
+
+	err := marshal.Unmarshal(serdes.Map{"4": "12A"}, &msg)
+	var marshalErr marshal.Error
+	assert.True(t, errors.As(err, &marshalErr))
+	assert.Equal(t, "4", marshalErr.Path)
+
+	err = marshal.Unmarshal(serdes.Map{"60": "invalid"}, &msg)
+	assert.EqualError(t, err, "invalid value [string], expected: map[string]interface {}: path: 60.")
+
+	err = marshal.Unmarshal(serdes.Map{"60": serdes.Map{"1": serdes.Map{}}}, &msg)
+	assert.EqualError(t, err, "invalid value [map[string]interface {}], expected: string: path: 60.1.")
+}
+
+func Test_Marshal_Roundtrip_With_Spec(t *testing.T) {
+	spec := types.BitMapped{
+		Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128},
+		Mapping: map[int]serdes.Serdes{
+			2:  types.VarLength{Length: types.Byte{}, Data: types.Bcd{}},
+			4:  types.Bcd{NumDigits: 12},
+			11: types.Bcd{NumDigits: 6},
+			52: types.Raw{NumBytes: 8},
+			60: types.VarLength{Length: types.Byte{}, Data: types.List{
+				Items: []types.Field{
+					{Name: "1", SerDes: types.Bcd{NumDigits: 1, NotPadded: true}},
+					{Name: "2", SerDes: types.Bcd{NumDigits: 1, NotPadded: true}},
+				},
+			}},
+		},
+	}
+
+	type message struct {
+		PAN      string   `iso8583:"2"`
+		Amount   int64    `iso8583:"4"`
+		STAN     int      `iso8583:"11"`
+		PinBlock []byte   `iso8583:"52"`
+		POS      *posData `iso8583:"60"`
+	}
+
+	in := message{
+		PAN:      "4111111111111111",
+		Amount:   1500,
+		STAN:     42,
+		PinBlock: []byte{0xAA, 0xBB, 0xCC, 0xDD, 0xEE, 0xFF, 0x00, 0x11},
+		POS:      &posData{TerminalType: "0", EntryMode: "5"},
+	}
+
+	values, err := marshal.Marshal(in)
+	assert.NoError(t, err)
+
+	data, err := spec.Serialize(values)
+	assert.NoError(t, err)
+
+	decoded, err := spec.Deserialize(data)
+	assert.NoError(t, err)
+
+	var out message
+	assert.NoError(t, marshal.Unmarshal(decoded.(serdes.Map), &out))
+	assert.Equal(t, in, out)
+}
@@ -0,0 +1,303 @@
+// Package overlay derives serdes trees from a base one by setting or removing fields, without modifying the base.
+//
+// The fields are addressed with the same paths of the values they decode, joined by serdes.PathSeparator: the bit
+// numbers of a BitMapped, the item names of a List and the tags of a TLV or BerTLV. VarLength fields are transparent
+// and the fields of anonymous List items are found from the List, example:
+//
+//	derived, report, err := overlay.Apply(specs.Mastercard(),
+//		overlay.Set("48.se.99", types.Ebcdic{Desc: "Host data"}),
+//		overlay.Remove("127"),
+//	)
+package overlay
+
+import (
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// Error is the error of the package, see serdes.Error.
+type Error = serdes.Error
+
+// Op is an operation over the field of a path, a nil SerDes removes the field.
+type Op struct {
+	Path   string
+	SerDes serdes.Serdes
+}
+
+// Set adds the field in the path, or replaces it when it exists.
+func Set(path string, s serdes.Serdes) Op {
+	return Op{Path: path, SerDes: s}
+}
+
+// Remove deletes the field in the path.
+func Remove(path string) Op {
+	return Op{Path: path}
+}
+
+type ChangeKind string
+
+const (
+	Added    ChangeKind = "added"
+	Replaced ChangeKind = "replaced"
+	Removed  ChangeKind = "removed"
+)
+
+// Change is a field changed by an overlay, Old is nil for added fields and New is nil for removed ones.
+type Change struct {
+	Path string
+	Kind ChangeKind
+	Old  serdes.Serdes
+	New  serdes.Serdes
+}
+
+// Report lists the changes of the operations, in the order they were applied.
+type Report struct {
+	Changes []Change
+}
+
+// Apply returns a copy of the base with the operations applied in order. The base is not modified, the derived tree
+// only shares with it the fields that were not changed.
+func Apply(base serdes.Serdes, ops ...Op) (serdes.Serdes, *Report, error) {
+	report := &Report{}
+	derived := base
+	for _, op := range ops {
+		segments := serdes.SplitPath(op.Path)
+		if len(segments) == 0 {
+			return nil, nil, Error{Message: "invalid operation", Cause: serdes.ErrEmptyPath}
+		}
+
+		applier := &applier{op: op}
+		result, err := applier.apply(derived, segments, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		derived = result
+		report.Changes = append(report.Changes, applier.change)
+	}
+
+	return derived, report, nil
+}
+
+type applier struct {
+	op     Op
+	change Change
+}
+
+// apply returns a copy of s with the operation applied over the field of segments[index:].
+func (a *applier) apply(s serdes.Serdes, segments []string, index int) (serdes.Serdes, error) {
+	switch value := s.(type) {
+	case types.VarLength:
+		data, err := a.apply(value.Data, segments, index)
+		if err != nil {
+			return nil, err
+		}
+		value.Data = data
+		return value, nil
+	case types.BitMapped:
+		return a.applyBitMapped(value, segments, index)
+	case types.List:
+		items, err := a.applyList(value.Items, segments, index)
+		if err != nil {
+			return nil, err
+		}
+		value.Items = items
+		return value, nil
+	case types.TLV:
+		items, err := a.applyItems(value.Items, segments, index)
+		if err != nil {
+			return nil, err
+		}
+		value.Items = items
+		return value, nil
+	case types.BerTLV:
+		items, err := a.applyItems(value.Items, segments, index)
+		if err != nil {
+			return nil, err
+		}
+		value.Items = items
+		return value, nil
+	}
+
+	return nil, a.notFound(segments, index)
+}
+
+func (a *applier) applyBitMapped(bitMapped types.BitMapped, segments []string, index int) (serdes.Serdes, error) {
+	key := segments[index]
+	bit, err := strconv.Atoi(key)
+	if err != nil || bit < 1 {
+		return nil, Error{Message: "invalid bit number", Path: a.path(segments, index), Cause: err}
+	}
+
+	field, exists := bitMapped.Mapping[bit]
+	mapping := make(map[int]serdes.Serdes, len(bitMapped.Mapping)+1)
+	for bitNumber, fieldSerdes := range bitMapped.Mapping {
+		mapping[bitNumber] = fieldSerdes
+	}
+
+	if index < len(segments)-1 {
+		if !exists {
+			return nil, a.notFound(segments, index)
+		}
+
+		child, err := a.apply(field, segments, index+1)
+		if err != nil {
+			return nil, err
+		}
+		mapping[bit] = child
+	} else {
+		if err := a.record(field, exists, segments); err != nil {
+			return nil, err
+		}
+
+		if a.op.SerDes == nil {
+			delete(mapping, bit)
+		} else {
+			mapping[bit] = a.op.SerDes
+		}
+	}
+
+	bitMapped.Mapping = mapping
+	return bitMapped, nil
+}
+
+// applyList applies the operation over the named items, or over the anonymous items that have the key. New fields
+// are added to the first anonymous BitMapped when the key is a bit number, otherwise they are appended to the list.
+func (a *applier) applyList(items []types.Field, segments []string, index int) ([]types.Field, error) {
+	key := segments[index]
+	if position := find(items, key); position >= 0 {
+		return a.applyItems(items, segments, index)
+	}
+
+	last := index == len(segments)-1
+	for position, item := range items {
+		if item.Name != "" || !has(item.SerDes, key) {
+			continue
+		}
+		return a.replaceItem(items, position, segments, index)
+	}
+
+	if last && a.op.SerDes != nil {
+		if _, err := strconv.Atoi(key); err == nil {
+			for position, item := range items {
+				if _, ok := unwrap(item.SerDes).(types.BitMapped); ok && item.Name == "" {
+					return a.replaceItem(items, position, segments, index)
+				}
+			}
+		}
+	}
+
+	return a.applyItems(items, segments, index)
+}
+
+func (a *applier) replaceItem(items []types.Field, position int, segments []string, index int) ([]types.Field, error) {
+	child, err := a.apply(items[position].SerDes, segments, index)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := append([]types.Field{}, items...)
+	copied[position].SerDes = child
+	return copied, nil
+}
+
+// applyItems applies the operation over the items addressed by name.
+func (a *applier) applyItems(items []types.Field, segments []string, index int) ([]types.Field, error) {
+	key := segments[index]
+	position := find(items, key)
+	if index < len(segments)-1 {
+		if position < 0 {
+			return nil, a.notFound(segments, index)
+		}
+
+		child, err := a.apply(items[position].SerDes, segments, index+1)
+		if err != nil {
+			return nil, err
+		}
+
+		copied := append([]types.Field{}, items...)
+		copied[position].SerDes = child
+		return copied, nil
+	}
+
+	var old serdes.Serdes
+	if position >= 0 {
+		old = items[position].SerDes
+	}
+
+	if err := a.record(old, position >= 0, segments); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case a.op.SerDes == nil:
+		copied := append([]types.Field{}, items[:position]...)
+		return append(copied, items[position+1:]...), nil
+	case position < 0:
+		copied := append([]types.Field{}, items...)
+		return append(copied, types.Field{Name: key, SerDes: a.op.SerDes}), nil
+	default:
+		copied := append([]types.Field{}, items...)
+		copied[position].SerDes = a.op.SerDes
+		return copied, nil
+	}
+}
+
+// record keeps the change done by the operation, removing a field that does not exist is an error.
+func (a *applier) record(old serdes.Serdes, exists bool, segments []string) error {
+	a.change = Change{Path: a.op.Path, Old: old, New: a.op.SerDes}
+	switch {
+	case a.op.SerDes == nil && !exists:
+		return a.notFound(segments, len(segments)-1)
+	case a.op.SerDes == nil:
+		a.change.Kind = Removed
+	case exists:
+		a.change.Kind = Replaced
+	default:
+		a.change.Kind = Added
+	}
+	return nil
+}
+
+func (a *applier) path(segments []string, index int) string {
+	return serdes.JoinPath(segments[:index+1]...)
+}
+
+func (a *applier) notFound(segments []string, index int) error {
+	return Error{Message: "field not found", Path: a.path(segments, index), Cause: serdes.ErrPathNotFound}
+}
+
+func find(items []types.Field, name string) int {
+	for position, item := range items {
+		if item.Name != "" && item.Name == name {
+			return position
+		}
+	}
+	return -1
+}
+
+// has returns if the serdes has a field with the key.
+func has(s serdes.Serdes, key string) bool {
+	switch value := unwrap(s).(type) {
+	case types.BitMapped:
+		bit, err := strconv.Atoi(key)
+		_, exists := value.Mapping[bit]
+		return err == nil && exists
+	case types.List:
+		for _, item := range value.Items {
+			if item.Name == key || (item.Name == "" && has(item.SerDes, key)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func unwrap(s serdes.Serdes) serdes.Serdes {
+	if varLen, ok := s.(types.VarLength); ok {
+		return unwrap(varLen.Data)
+	}
+	return s
+}
@@ -0,0 +1,106 @@
+package overlay_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/overlay"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func bitMappedOf(s serdes.Serdes) types.BitMapped {
+	return s.(types.List).Items[1].SerDes.(types.BitMapped)
+}
+
+func Test_Apply_BitMapped_Fields(t *testing.T) {
+	base := specs.ISO87ASCII(specs.HexBitmap)
+	field48 := types.VarLength{Desc: "Host data", Length: types.AsciiNumeric{NumDigits: 3}, Data: types.List{
+		Items: []types.Field{{Name: "channel", SerDes: types.Ascii{NumDigits: 2}}, {Name: "store", SerDes: types.Ascii{}}},
+	}}
+
+	derived, report, err := overlay.Apply(base,
+		overlay.Set("48", field48),
+		overlay.Remove("127"),
+		overlay.Set("48.terminal", types.Ascii{Desc: "Terminal", NumDigits: 4}),
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, field48.Desc, bitMappedOf(derived).Mapping[48].(types.VarLength).Desc)
+	assert.NotContains(t, bitMappedOf(derived).Mapping, 127)
+	assert.Len(t, bitMappedOf(derived).Mapping[48].(types.VarLength).Data.(types.List).Items, 3)
+
+	// the base is not modified.
+	assert.Equal(t, types.Desc("Additional data, private"), bitMappedOf(base).Mapping[48].(types.VarLength).Desc)
+	assert.Contains(t, bitMappedOf(base).Mapping, 127)
+	assert.Len(t, field48.Data.(types.List).Items, 2)
+
+	assert.Equal(t, []overlay.Change{
+		{Path: "48", Kind: overlay.Replaced, Old: bitMappedOf(base).Mapping[48], New: field48},
+		{Path: "127", Kind: overlay.Removed, Old: bitMappedOf(base).Mapping[127]},
+		{Path: "48.terminal", Kind: overlay.Added, New: types.Ascii{Desc: "Terminal", NumDigits: 4}},
+	}, report.Changes)
+
+	buffer, err := derived.Serialize(serdes.Map{specs.MTIField: "0100", "48": serdes.Map{"channel": "01", "store": "A1", "terminal": "T1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "0100"+"0000000000010000"+"008"+"01A1T1  ", buffer.String())
+}
+
+func Test_Apply_Nested_TLV(t *testing.T) {
+	base := specs.Mastercard()
+
+	derived, report, err := overlay.Apply(base,
+		overlay.Set("48.se.99", types.Ebcdic{Desc: "Host data"}),
+		overlay.Remove("48.se.95"),
+		overlay.Set("55.9f6e", types.Raw{Desc: "Third party data"}),
+		overlay.Set("mti", types.Bcd{NumDigits: 4, NotPadded: true}),
+		overlay.Set("130", types.Ebcdic{}),
+	)
+	assert.NoError(t, err)
+	assert.Len(t, report.Changes, 5)
+	assert.Equal(t, overlay.Added, report.Changes[0].Kind)
+	assert.Equal(t, overlay.Removed, report.Changes[1].Kind)
+	assert.Equal(t, overlay.Replaced, report.Changes[2].Kind)
+	assert.Equal(t, overlay.Replaced, report.Changes[3].Kind)
+	assert.Equal(t, overlay.Added, report.Changes[4].Kind)
+	assert.Contains(t, bitMappedOf(derived).Mapping, 130)
+	assert.NotContains(t, bitMappedOf(base).Mapping, 130)
+
+	buffer, err := derived.Serialize(serdes.Map{specs.MTIField: "0100", "48": serdes.Map{"tcc": "R", "se": serdes.Map{"95": "AB", "99": "XY"}}})
+	assert.NoError(t, err)
+
+	value, err := derived.Deserialize(buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{specs.MTIField: "0100", "48": serdes.Map{"tcc": "R", "se": serdes.Map{"99": "XY"}}}, value)
+
+	// the base still has the subelement 95 and not the 99.
+	buffer, err = base.Serialize(serdes.Map{specs.MTIField: "0100", "48": serdes.Map{"tcc": "R", "se": serdes.Map{"95": "AB", "99": "XY"}}})
+	assert.NoError(t, err)
+
+	value, err = base.Deserialize(buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{specs.MTIField: "0100", "48": serdes.Map{"tcc": "R", "se": serdes.Map{"95": "AB"}}}, value)
+}
+
+func Test_Apply_Errors(t *testing.T) {
+	base := specs.ISO87ASCII(specs.HexBitmap)
+
+	_, _, err := overlay.Apply(base, overlay.Remove("48.se"))
+	assert.EqualError(t, err, "field not found: path: 48.se. -> path not found")
+	assert.True(t, errors.Is(err, serdes.ErrPathNotFound))
+
+	_, _, err = overlay.Apply(base, overlay.Remove("200"))
+	assert.True(t, errors.Is(err, serdes.ErrPathNotFound))
+
+	_, _, err = overlay.Apply(base, overlay.Set("2.x.y", types.Ascii{}))
+	assert.True(t, errors.Is(err, serdes.ErrPathNotFound))
+
+	_, _, err = overlay.Apply(types.BitMapped{}, overlay.Set("tcc", types.Ascii{}))
+	assert.EqualError(t, err, `invalid bit number: path: tcc. -> strconv.Atoi: parsing "tcc": invalid syntax`)
+
+	_, _, err = overlay.Apply(base, overlay.Set("", types.Ascii{}))
+	assert.True(t, errors.Is(err, serdes.ErrEmptyPath))
+}
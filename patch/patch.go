@@ -0,0 +1,465 @@
+// Package patch sets and removes fields of encoded messages without decoding and encoding the whole message, so the
+// bytes of the other fields are kept as they were received.
+//
+// The fields are addressed with the same paths of the values they decode, joined by serdes.PathSeparator: the bit
+// numbers of a BitMapped, the item names of a List and the tags of a TLV or BerTLV. VarLength fields are transparent
+// and the fields of anonymous List items are found from the List. The bitmaps of the patched BitMapped fields and the
+// length prefixes of the enclosing VarLength fields and TLV tags are encoded again, example:
+//
+//	response, err := patch.Apply(specs.Mastercard(), request,
+//		patch.Set("mti", "0110"),
+//		patch.Set("39", "00"),
+//		patch.Set("48.se.21", "01010"),
+//		patch.Remove("52"),
+//	)
+package patch
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// Error is the error of the package, see serdes.Error.
+type Error = serdes.Error
+
+// Op is an operation over the field of a path.
+type Op struct {
+	Path   string
+	Value  serdes.Value
+	Remove bool
+}
+
+// Set encodes the value in the field of the path, the field is added when the message doesn't have it.
+func Set(path string, value serdes.Value) Op {
+	return Op{Path: path, Value: value}
+}
+
+// Remove deletes the field of the path, nothing is done when the message doesn't have it.
+func Remove(path string) Op {
+	return Op{Path: path, Remove: true}
+}
+
+// op is an operation whose path is relative to the patched field.
+type op struct {
+	Op
+	segments []string
+}
+
+func (o op) key() string {
+	return o.segments[0]
+}
+
+func (o op) leaf() bool {
+	return len(o.segments) == 1
+}
+
+func (o op) child() op {
+	return op{Op: o.Op, segments: o.segments[1:]}
+}
+
+// Apply applies the operations in order to the encoded message and returns the patched message, data is not modified.
+func Apply(spec serdes.Serdes, data []byte, ops ...Op) ([]byte, error) {
+	relative := make([]op, 0, len(ops))
+	for _, o := range ops {
+		if o.Path == "" {
+			return nil, Error{Message: "empty path"}
+		}
+		relative = append(relative, op{Op: o, segments: serdes.SplitPath(o.Path)})
+	}
+
+	end, err := scan("", spec, data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := patch("", spec, data[:end], relative)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(patched)+len(data)-end)
+	return append(append(out, patched...), data[end:]...), nil
+}
+
+// patch applies the operations to the encoded field, the data is returned as it is when there are no operations.
+func patch(path string, s serdes.Serdes, data []byte, ops []op) ([]byte, error) {
+	if len(ops) == 0 {
+		return data, nil
+	}
+
+	switch value := s.(type) {
+	case types.VarLength:
+		return patchVarLength(path, value, data, ops)
+	case types.List:
+		return patchList(path, value, data, ops)
+	case types.BitMapped:
+		return patchBitMapped(path, value, data, ops)
+	case types.TLV:
+		return patchTLV(path, value, data, ops)
+	case types.BerTLV:
+		return patchBerTLV(path, value, data, ops)
+	}
+	return nil, Error{Message: "field has no subfields", Path: serdes.JoinPath(path, ops[0].key()), Cause: serdes.ErrPathNotFound}
+}
+
+// patchVarLength patches the data and encodes the length prefix of the patched data.
+func patchVarLength(path string, varLen types.VarLength, data []byte, ops []op) ([]byte, error) {
+	dataStart, _, err := types.ScanVarLength(varLen, data)
+	if err != nil {
+		return nil, Error{Message: "error scanning field", Path: path, Cause: err}
+	}
+
+	patched, err := patch(path, varLen.Data, data[dataStart:], ops)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := varLen.AppendEncoded(nil, patched)
+	if err != nil {
+		return nil, Error{Message: "error encoding length", Path: path, Cause: err}
+	}
+	return out, nil
+}
+
+// patchList patches the items of the list, the operations of the keys that are not named items are applied to the
+// anonymous items that have the key.
+func patchList(path string, list types.List, data []byte, ops []op) ([]byte, error) {
+	pending := make([]bool, len(ops))
+	for i := range pending {
+		pending[i] = true
+	}
+
+	var out []byte
+	off := 0
+	for _, item := range list.Items {
+		start := off
+		if off < len(data) {
+			var err error
+			itemPath := path
+			if item.Name != "" {
+				itemPath = serdes.JoinPath(path, item.Name)
+			}
+			if off, err = scan(itemPath, item.SerDes, data, off); err != nil {
+				return nil, err
+			}
+		}
+
+		var itemOps []op
+		for i, o := range ops {
+			if pending[i] && owns(item, o.key()) {
+				itemOps = append(itemOps, o)
+				pending[i] = false
+			}
+		}
+
+		var err error
+		var patched []byte
+		if item.Name == "" {
+			patched, err = patch(path, item.SerDes, data[start:off], itemOps)
+		} else {
+			patched, _, err = patchField(path, item.Name, item.SerDes, data[start:off], start != off, itemOps)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, patched...)
+	}
+
+	for i, o := range ops {
+		if pending[i] {
+			return nil, Error{Message: "field not found", Path: serdes.JoinPath(path, o.key()), Cause: serdes.ErrPathNotFound}
+		}
+	}
+	return append(out, data[off:]...), nil
+}
+
+// owns returns true when the key is the name of the item or a key of the anonymous item.
+func owns(item types.Field, key string) bool {
+	if item.Name != "" {
+		return item.Name == key
+	}
+
+	switch value := item.SerDes.(type) {
+	case types.List:
+		for _, listItem := range value.Items {
+			if owns(listItem, key) {
+				return true
+			}
+		}
+	case types.BitMapped:
+		bit, err := strconv.Atoi(key)
+		return err == nil && strconv.Itoa(bit) == key && value.Mapping[bit] != nil
+	}
+	return false
+}
+
+// patchField applies the operations of a field in order, the operations of its subfields patch the field as it was
+// left by the previous operations. It returns false when the field was removed.
+func patchField(path, key string, s serdes.Serdes, data []byte, present bool, ops []op) ([]byte, bool, error) {
+	fieldPath := serdes.JoinPath(path, key)
+	for _, o := range ops {
+		switch {
+		case o.leaf() && o.Remove:
+			data, present = nil, false
+		case o.leaf():
+			encoded, err := serdes.AppendSerialize(nil, s, o.Value)
+			if err != nil {
+				return nil, false, Error{Message: "error encoding field", Path: fieldPath, Cause: err}
+			}
+			data, present = encoded, true
+		case !present:
+			return nil, false, Error{Message: "field not found", Path: fieldPath, Cause: serdes.ErrPathNotFound}
+		default:
+			patched, err := patch(fieldPath, s, data, []op{o.child()})
+			if err != nil {
+				return nil, false, err
+			}
+			data = patched
+		}
+	}
+	return data, present, nil
+}
+
+// patchBitMapped patches the fields of the bits, the bitmap is encoded again when bits are set or cleared.
+func patchBitMapped(path string, bitMapped types.BitMapped, data []byte, ops []op) ([]byte, error) {
+	bits, bitmapEnd, err := scanBitmap(path, bitMapped.Bitmap, data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[int][]byte{}
+	off := bitmapEnd
+	for _, bit := range bits {
+		bitPath := serdes.JoinPath(path, strconv.Itoa(bit))
+		fieldSerdes := bitMapped.Mapping[bit]
+		if fieldSerdes == nil {
+			return nil, Error{Message: "field not found", Path: bitPath, Cause: serdes.ErrPathNotFound}
+		}
+
+		start := off
+		if off, err = scan(bitPath, fieldSerdes, data, off); err != nil {
+			return nil, err
+		}
+		fields[bit] = data[start:off]
+	}
+
+	var order []int
+	bitOps := map[int][]op{}
+	for _, o := range ops {
+		bit, err := strconv.Atoi(o.key())
+		if err != nil || strconv.Itoa(bit) != o.key() || bitMapped.Mapping[bit] == nil {
+			return nil, Error{Message: "field not found", Path: serdes.JoinPath(path, o.key()), Cause: serdes.ErrPathNotFound}
+		}
+
+		if _, exists := bitOps[bit]; !exists {
+			order = append(order, bit)
+		}
+		bitOps[bit] = append(bitOps[bit], o)
+	}
+
+	changed := false
+	for _, bit := range order {
+		raw, present := fields[bit]
+		patched, isSet, err := patchField(path, strconv.Itoa(bit), bitMapped.Mapping[bit], raw, present, bitOps[bit])
+		if err != nil {
+			return nil, err
+		}
+
+		if isSet != present {
+			changed = true
+		}
+
+		if isSet {
+			fields[bit] = patched
+		} else {
+			delete(fields, bit)
+		}
+	}
+
+	var out []byte
+	if changed {
+		if out, err = encodeBitmap(path, bitMapped.Bitmap, fields); err != nil {
+			return nil, err
+		}
+	} else {
+		out = append(out, data[:bitmapEnd]...)
+	}
+
+	for bit := 1; len(fields) > 0; bit++ {
+		if raw, exists := fields[bit]; exists {
+			out = append(out, raw...)
+			delete(fields, bit)
+		}
+	}
+	return append(out, data[off:]...), nil
+}
+
+// encodeBitmap encodes the bitmap of the bits of the fields, no bitmap is encoded when there are no fields.
+func encodeBitmap(path string, bitmap types.Bitmap, fields map[int][]byte) ([]byte, error) {
+	lastBit := 0
+	for bit := range fields {
+		if bit > lastBit {
+			lastBit = bit
+		}
+	}
+
+	if lastBit == 0 {
+		return nil, nil
+	}
+
+	bits := make([]byte, (lastBit+7)/8)
+	for bit := range fields {
+		bits[(bit-1)/8] |= 0x80 >> uint((bit-1)%8)
+	}
+
+	out, err := serdes.AppendSerialize(nil, bitmap, bits)
+	if err != nil {
+		return nil, Error{Message: "error encoding bitmap", Path: path, Cause: err}
+	}
+	return out, nil
+}
+
+// tagValue is an encoded TLV or BerTLV tag.
+type tagValue struct {
+	key   string
+	raw   []byte
+	value []byte
+}
+
+// patchTLV patches the values of the tags, the tags that are added are encoded before the first tag listed after them
+// in the items of the TLV.
+func patchTLV(path string, tlv types.TLV, data []byte, ops []op) ([]byte, error) {
+	encode := func(key string, value []byte) ([]byte, error) {
+		single := types.TLV{SizeTag: tlv.SizeTag, SizeLen: tlv.SizeLen, Ascii: tlv.Ascii, Items: []types.Field{{Name: key, SerDes: types.Raw{}}}}
+		return encodeTag(path, key, single, value)
+	}
+	return patchTags(path, tlv, tlv.Items, data, ops, encode)
+}
+
+// patchBerTLV patches the values of the tags like patchTLV, the tags that are not listed in the items are kept.
+func patchBerTLV(path string, berTLV types.BerTLV, data []byte, ops []op) ([]byte, error) {
+	encode := func(key string, value []byte) ([]byte, error) {
+		single := types.BerTLV{SizeLen: berTLV.SizeLen, Items: []types.Field{{Name: key, SerDes: types.Raw{}}}}
+		return encodeTag(path, key, single, value)
+	}
+	return patchTags(path, berTLV, berTLV.Items, data, ops, encode)
+}
+
+// encodeTag encodes the tag, the length and the encoded value with the TLV or BerTLV of the tag.
+func encodeTag(path, key string, s serdes.Serdes, value []byte) ([]byte, error) {
+	raw, err := serdes.AppendSerialize(nil, s, serdes.Map{key: hex.EncodeToString(value)})
+	if err != nil {
+		return nil, Error{Message: "error encoding tag", Path: serdes.JoinPath(path, key), Cause: err}
+	}
+	return raw, nil
+}
+
+func patchTags(path string, s serdes.Serdes, items []types.Field, data []byte, ops []op,
+	encode func(key string, value []byte) ([]byte, error)) ([]byte, error) {
+	ranges, err := types.ScanTags(s, data)
+	if err != nil {
+		return nil, Error{Message: "error scanning tags", Path: path, Cause: err}
+	}
+
+	tags := make([]tagValue, 0, len(ranges))
+	for _, tag := range ranges {
+		tags = append(tags, tagValue{key: tag.Key, raw: data[tag.Start:tag.End], value: data[tag.Value:tag.End]})
+	}
+
+	for _, o := range ops {
+		index := itemIndex(items, o.key())
+		if index < 0 {
+			return nil, Error{Message: "field not found", Path: serdes.JoinPath(path, o.key()), Cause: serdes.ErrPathNotFound}
+		}
+
+		position, present := -1, false
+		for i, tag := range tags {
+			if tag.key == o.key() {
+				position, present = i, true
+				break
+			}
+		}
+
+		var value []byte
+		if present {
+			value = tags[position].value
+		}
+
+		patched, isSet, err := patchField(path, o.key(), items[index].SerDes, value, present, []op{o})
+		if err != nil {
+			return nil, err
+		}
+
+		if !isSet {
+			if present {
+				tags = append(tags[:position], tags[position+1:]...)
+			}
+			continue
+		}
+
+		raw, err := encode(o.key(), patched)
+		if err != nil {
+			return nil, err
+		}
+
+		tag := tagValue{key: o.key(), raw: raw, value: patched}
+		if present {
+			tags[position] = tag
+			continue
+		}
+
+		position = len(tags)
+		for i, existing := range tags {
+			if itemIndex(items, existing.key) > index {
+				position = i
+				break
+			}
+		}
+		tags = append(tags[:position], append([]tagValue{tag}, tags[position:]...)...)
+	}
+
+	var out []byte
+	for _, tag := range tags {
+		out = append(out, tag.raw...)
+	}
+	return out, nil
+}
+
+func itemIndex(items []types.Field, key string) int {
+	for index, item := range items {
+		if item.Name == key {
+			return index
+		}
+	}
+	return -1
+}
+
+// scan returns the end of the field that starts in off, see types.Scan. The path of the error is the path of the
+// subfield that failed.
+func scan(path string, s serdes.Serdes, data []byte, off int) (int, error) {
+	size, err := types.Scan(s, data[off:])
+	if err != nil {
+		var deserializationErr types.DeserializationError
+		if errors.As(err, &deserializationErr) {
+			path = serdes.JoinPath(path, deserializationErr.Path)
+		}
+		return off, Error{Message: "error scanning field", Path: path, Cause: err}
+	}
+	return off + size, nil
+}
+
+// scanBitmap decodes the bitmap, see types.ScanBitmap. A BitMapped without data has no bitmap.
+func scanBitmap(path string, bitmap types.Bitmap, data []byte) ([]int, int, error) {
+	if len(data) == 0 {
+		return nil, 0, nil
+	}
+
+	bits, size, err := types.ScanBitmap(bitmap, data)
+	if err != nil {
+		return nil, 0, Error{Message: "error decoding bitmap", Path: path, Cause: err}
+	}
+	return bits, size, nil
+}
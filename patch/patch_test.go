@@ -0,0 +1,153 @@
+package patch_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/internal/testspec"
+	"github.com/mercadolibre/go-iso8583/patch"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encode(t *testing.T, value serdes.Map) []byte {
+	data, err := testspec.Spec().Serialize(value)
+	assert.NoError(t, err)
+	return data.Bytes()
+}
+
+func Test_Apply(t *testing.T) {
+	data := encode(t, testspec.Message())
+	original := append([]byte{}, data...)
+
+	patched, err := patch.Apply(testspec.Spec(), data,
+		patch.Set("mti", "0110"),
+		patch.Set("39", "00"),
+		patch.Set("32", "1234"),
+		patch.Remove("2"),
+		patch.Remove("70"),
+		patch.Set("48.se.21", "1"),
+		patch.Set("48.se.42", "ABC"),
+		patch.Remove("48.se.61"),
+		patch.Set("55.9f36", "0002"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, original, data)
+
+	expected := testspec.Message()
+	expected["mti"] = "0110"
+	expected["39"] = "00"
+	expected["32"] = "1234"
+	delete(expected, "2")
+	delete(expected, "70")
+	expected["48"] = serdes.Map{"tcc": "R", "se": serdes.Map{"21": "1", "42": "ABC"}}
+	expected["55"] = serdes.Map{"9f26": "0123456789abcdef", "9f36": "0002"}
+	assert.Equal(t, encode(t, expected), patched)
+}
+
+func Test_Apply_Keeps_Other_Bytes(t *testing.T) {
+	// the tag 5f2a of the field 55 is not in the spec, decoding and encoding the message would drop it.
+	data := []byte("0100" + "2000000000000200" + "000000")
+	data = append(data, 0x00, 0x08, 0x5f, 0x2a, 0x01, 0x09, 0x9f, 0x26, 0x01, 0x01)
+
+	patched, err := patch.Apply(testspec.Spec(), data, patch.Set("3", "001000"), patch.Set("55.9f36", "0001"))
+	assert.NoError(t, err)
+
+	expected := []byte("0100" + "2000000000000200" + "001000")
+	expected = append(expected, 0x00, 0x0d, 0x5f, 0x2a, 0x01, 0x09, 0x9f, 0x26, 0x01, 0x01, 0x9f, 0x36, 0x02, 0x00, 0x01)
+	assert.Equal(t, expected, patched)
+
+	patched, err = patch.Apply(testspec.Spec(), append(data, "trailing"...), patch.Remove("55.9f26"))
+	assert.NoError(t, err)
+
+	expected = []byte("0100" + "2000000000000200" + "000000")
+	expected = append(expected, 0x00, 0x04, 0x5f, 0x2a, 0x01, 0x09)
+	assert.Equal(t, append(expected, "trailing"...), patched)
+}
+
+func Test_Apply_Bitmap(t *testing.T) {
+	data := encode(t, serdes.Map{"mti": "0800", "3": "000000"})
+
+	patched, err := patch.Apply(testspec.Spec(), data, patch.Remove("39"))
+	assert.NoError(t, err)
+	assert.Equal(t, data, patched)
+
+	patched, err = patch.Apply(testspec.Spec(), data, patch.Set("70", "301"))
+	assert.NoError(t, err)
+	assert.Equal(t, encode(t, serdes.Map{"mti": "0800", "3": "000000", "70": "301"}), patched)
+
+	patched, err = patch.Apply(testspec.Spec(), patched, patch.Remove("70"), patch.Remove("3"))
+	assert.NoError(t, err)
+	assert.Equal(t, encode(t, serdes.Map{"mti": "0800"}), patched)
+
+	patched, err = patch.Apply(testspec.Spec(), patched, patch.Set("39", "00"))
+	assert.NoError(t, err)
+	assert.Equal(t, encode(t, serdes.Map{"mti": "0800", "39": "00"}), patched)
+}
+
+func Test_Apply_Errors(t *testing.T) {
+	data := encode(t, testspec.Message())
+
+	tests := []struct {
+		op  patch.Op
+		err string
+	}{
+		{patch.Set("", "0110"), "empty path"},
+		{patch.Set("99", "00"), "field not found: path: 99. -> path not found"},
+		{patch.Set("03", "000000"), "field not found: path: 03. -> path not found"},
+		{patch.Set("3.1", "0"), "field has no subfields: path: 3.1. -> path not found"},
+		{patch.Set("39.1", "0"), "field not found: path: 39. -> path not found"},
+		{patch.Set("48.se.99", "0"), "field not found: path: 48.se.99. -> path not found"},
+		{patch.Set("3", "1234567"), "error encoding field: path: 3. -> value too long: serializer: ascii_numeric. value type: string."},
+	}
+
+	for _, tt := range tests {
+		_, err := patch.Apply(testspec.Spec(), data, tt.op)
+		assert.EqualError(t, err, tt.err)
+	}
+
+	_, err := patch.Apply(testspec.Spec(), data, patch.Set("48.tcc.1", "0"))
+	assert.True(t, errors.Is(err, serdes.ErrPathNotFound))
+
+	_, err = patch.Apply(testspec.Spec(), data[:len(data)-1], patch.Set("39", "00"))
+	assert.True(t, errors.Is(err, types.ErrShortBuffer))
+	assert.Equal(t, "70", err.(patch.Error).Path)
+}
+
+func Test_Apply_Visa_VarLength(t *testing.T) {
+	spec := specs.VisaBaseI()
+	message := serdes.Map{
+		specs.HeaderField: serdes.Map{
+			"header_length":        "22",
+			"header_format":        "1",
+			"text_format":          "2",
+			"message_length":       "0",
+			"destination_id":       "456789",
+			"source_id":            "123456",
+			"round_trip_info":      "0",
+			"base_i_flags":         "0000",
+			"message_status_flags": "000000",
+			"batch_number":         "0",
+			"reserved":             "000000",
+			"user_info":            "0",
+		},
+		specs.MTIField: "0100",
+		"2":            "4761739001010010",
+		"3":            "000000",
+		"62":           serdes.Map{"1": "Y", "2": "012345678901234"},
+	}
+	data, err := spec.Serialize(message)
+	assert.NoError(t, err)
+
+	patched, err := patch.Apply(spec, data.Bytes(), patch.Remove("62.1"), patch.Set("2", "476173900101001"))
+	assert.NoError(t, err)
+
+	message["2"] = "476173900101001"
+	message["62"] = serdes.Map{"2": "012345678901234"}
+	expected, err := spec.Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Bytes(), patched)
+}
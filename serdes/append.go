@@ -0,0 +1,55 @@
+package serdes
+
+import "bytes"
+
+// Appender is implemented by the serdes that encode the value appending it to a byte slice, so composed serdes can
+// encode a whole message into a single slice that callers reuse between messages. On error dst is returned unchanged.
+type Appender interface {
+	AppendSerialize(dst []byte, value Value) ([]byte, error)
+}
+
+// MapDeserializer is implemented by the serdes that decode a map into an existing one, so callers can reuse the map
+// between messages. The map is cleared before decoding.
+type MapDeserializer interface {
+	DeserializeInto(data *bytes.Buffer, dst Map) error
+}
+
+// AppendSerialize appends the encoded value to dst, the serdes that don't implement Appender are encoded with
+// Serialize.
+func AppendSerialize(dst []byte, s Serializer, value Value) ([]byte, error) {
+	if appender, ok := s.(Appender); ok {
+		return appender.AppendSerialize(dst, value)
+	}
+
+	data, err := s.Serialize(value)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, data.Bytes()...), nil
+}
+
+// DeserializeInto clears dst and decodes the map into it, the serdes that don't implement MapDeserializer are decoded
+// with Deserialize and their value copied into dst.
+func DeserializeInto(data *bytes.Buffer, s Deserialize, dst Map) error {
+	if deserializer, ok := s.(MapDeserializer); ok {
+		return deserializer.DeserializeInto(data, dst)
+	}
+
+	value, err := s.Deserialize(data)
+	if err != nil {
+		return err
+	}
+
+	mapValue, ok := value.(Map)
+	if !ok {
+		return ErrNotMap
+	}
+
+	for key := range dst {
+		delete(dst, key)
+	}
+	for key, item := range mapValue {
+		dst[key] = item
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package serdes_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AppendSerialize_Fallback(t *testing.T) {
+	ser := &serdes.Mock{}
+	ser.On("Serialize", "value").Return(bytes.NewBufferString("data"), nil)
+	ser.On("Serialize", "invalid").Return((*bytes.Buffer)(nil), errors.New("invalid value"))
+
+	data, err := serdes.AppendSerialize([]byte("prefix-"), ser, "value")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("prefix-data"), data)
+
+	_, err = serdes.AppendSerialize(nil, ser, "invalid")
+	assert.EqualError(t, err, "invalid value")
+}
+
+func Test_DeserializeInto_Fallback(t *testing.T) {
+	des := &serdes.Mock{}
+	data := bytes.NewBufferString("data")
+	des.On("Deserialize", data).Return(serdes.Map{"2": "123"}, nil)
+
+	dst := serdes.Map{"stale": "value"}
+	assert.NoError(t, serdes.DeserializeInto(data, des, dst))
+	assert.Equal(t, serdes.Map{"2": "123"}, dst)
+
+	value := bytes.NewBufferString("value")
+	des.On("Deserialize", value).Return("123", nil)
+	assert.Equal(t, serdes.ErrNotMap, serdes.DeserializeInto(value, des, dst))
+}
@@ -0,0 +1,28 @@
+package serdes
+
+import "fmt"
+
+// Error is the error of the packages built over the serdes, like spec, lazy or patch. Path is the path of the value or
+// field that failed, when there is one.
+type Error struct {
+	Message string `json:"message"`
+	Path    string `json:"path"`
+	Cause   error  `json:"cause"`
+}
+
+func (err Error) Error() string {
+	msg := err.Message
+	if err.Path != "" {
+		msg = fmt.Sprintf("%s: path: %s.", msg, err.Path)
+	}
+
+	if err.Cause != nil {
+		return fmt.Sprintf("%s -> %+v", msg, err.Cause)
+	}
+
+	return msg
+}
+
+func (err Error) Unwrap() error {
+	return err.Cause
+}
@@ -0,0 +1,179 @@
+package serdes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PathSeparator separates the segments of a path, example: "127.3.2", "55.9F26", "48.TCC".
+const PathSeparator = "."
+
+var (
+	ErrEmptyPath    = errors.New("empty path")
+	ErrPathNotFound = errors.New("path not found")
+	ErrNotMap       = errors.New("value is not a map")
+
+	// SkipMap can be returned by a WalkFunc to avoid walking into the map being visited.
+	SkipMap = errors.New("skip this map")
+)
+
+// PathError records the path and the segment where an operation over a Map failed.
+type PathError struct {
+	Op      string `json:"op"`
+	Path    string `json:"path"`
+	Segment string `json:"segment"`
+	Err     error  `json:"err"`
+}
+
+func (err PathError) Error() string {
+	return fmt.Sprintf("%s %s: segment %q: %v", err.Op, err.Path, err.Segment, err.Err)
+}
+
+func (err PathError) Unwrap() error {
+	return err.Err
+}
+
+// WalkFunc is called by Walk for every value found, the path of nested values are joined by PathSeparator.
+type WalkFunc func(path string, value Value) error
+
+// JoinPath joins the segments into a path, the empty segments are skipped.
+func JoinPath(segments ...string) string {
+	nonEmpty := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment != "" {
+			nonEmpty = append(nonEmpty, segment)
+		}
+	}
+	return strings.Join(nonEmpty, PathSeparator)
+}
+
+// SplitPath splits a path into its segments.
+func SplitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, PathSeparator)
+}
+
+// Get returns the value found in the path.
+func Get(m Map, path string) (Value, error) {
+	parent, key, err := parentOf("get", m, path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	value, exists := parent[key]
+	if !exists {
+		return nil, PathError{Op: "get", Path: path, Segment: key, Err: ErrPathNotFound}
+	}
+
+	return value, nil
+}
+
+// Set puts the value in the path, intermediate maps are created when they don't exist.
+func Set(m Map, path string, value Value) error {
+	parent, key, err := parentOf("set", m, path, true)
+	if err != nil {
+		return err
+	}
+
+	parent[key] = value
+	return nil
+}
+
+// Delete removes the value found in the path.
+func Delete(m Map, path string) error {
+	parent, key, err := parentOf("delete", m, path, false)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := parent[key]; !exists {
+		return PathError{Op: "delete", Path: path, Segment: key, Err: ErrPathNotFound}
+	}
+
+	delete(parent, key)
+	return nil
+}
+
+// Walk calls fn for every value of the map, in key order, visiting maps before their items.
+func Walk(m Map, fn WalkFunc) error {
+	return walk("", m, fn)
+}
+
+func walk(prefix string, m Map, fn WalkFunc) error {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path := JoinPath(prefix, key)
+
+		value := m[key]
+		err := fn(path, value)
+		if errors.Is(err, SkipMap) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if mapValue, ok := value.(Map); ok {
+			if err := walk(path, mapValue, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func parentOf(op string, m Map, path string, create bool) (Map, string, error) {
+	segments := SplitPath(path)
+	if len(segments) == 0 {
+		return nil, "", PathError{Op: op, Path: path, Err: ErrEmptyPath}
+	}
+
+	if m == nil {
+		return nil, "", PathError{Op: op, Path: path, Segment: segments[0], Err: ErrPathNotFound}
+	}
+
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, "", PathError{Op: op, Path: path, Segment: segment, Err: ErrEmptyPath}
+		}
+	}
+
+	current := m
+	last := len(segments) - 1
+	for index, segment := range segments[:last] {
+		value, exists := current[segment]
+		if !exists {
+			if !create {
+				return nil, "", PathError{Op: op, Path: path, Segment: segment, Err: ErrPathNotFound}
+			}
+
+			// the maps are created once the whole path was checked, a failed operation doesn't change the map.
+			for _, missing := range segments[index:last] {
+				created := Map{}
+				current[missing] = created
+				current = created
+			}
+			break
+		}
+
+		mapValue, ok := value.(Map)
+		if !ok {
+			return nil, "", PathError{Op: op, Path: path, Segment: segment, Err: ErrNotMap}
+		}
+
+		current = mapValue
+	}
+
+	return current, segments[last], nil
+}
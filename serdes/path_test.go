@@ -0,0 +1,167 @@
+package serdes_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPathMap() serdes.Map {
+	return serdes.Map{
+		"2": "4111111111111111",
+		"48": serdes.Map{
+			"TCC": "R",
+		},
+		"55": serdes.Map{
+			"9F26": "0102030405060708",
+		},
+		"127": serdes.Map{
+			"3": serdes.Map{
+				"2": "value",
+			},
+		},
+	}
+}
+
+func Test_Path_JoinPath(t *testing.T) {
+	assert.Equal(t, "127.3.2", serdes.JoinPath("127", "3", "2"))
+	assert.Equal(t, "48.TCC", serdes.JoinPath("", "48", "", "TCC"))
+	assert.Equal(t, "", serdes.JoinPath("", ""))
+	assert.Equal(t, "", serdes.JoinPath())
+}
+
+func Test_Path_Get(t *testing.T) {
+	m := newPathMap()
+
+	tests := []struct {
+		path    string
+		want    serdes.Value
+		wantErr error
+	}{
+		{path: "2", want: "4111111111111111"},
+		{path: "48.TCC", want: "R"},
+		{path: "55.9F26", want: "0102030405060708"},
+		{path: "127.3.2", want: "value"},
+		{path: "127.3", want: serdes.Map{"2": "value"}},
+		{path: "127.4.2", wantErr: serdes.ErrPathNotFound},
+		{path: "127.3.1", wantErr: serdes.ErrPathNotFound},
+		{path: "2.1", wantErr: serdes.ErrNotMap},
+		{path: "", wantErr: serdes.ErrEmptyPath},
+		{path: "127..2", wantErr: serdes.ErrEmptyPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := serdes.Get(m, tt.path)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Path_Get_Error_Segment(t *testing.T) {
+	_, err := serdes.Get(newPathMap(), "127.4.2")
+
+	var pathErr serdes.PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "get", pathErr.Op)
+	assert.Equal(t, "127.4.2", pathErr.Path)
+	assert.Equal(t, "4", pathErr.Segment)
+	assert.Equal(t, `get 127.4.2: segment "4": path not found`, err.Error())
+}
+
+func Test_Path_Set(t *testing.T) {
+	m := newPathMap()
+
+	assert.NoError(t, serdes.Set(m, "127.3.2", "new value"))
+	assert.NoError(t, serdes.Set(m, "127.25.1", "created"))
+	assert.NoError(t, serdes.Set(m, "39", "00"))
+
+	assert.Equal(t, "new value", m["127"].(serdes.Map)["3"].(serdes.Map)["2"])
+	assert.Equal(t, serdes.Map{"1": "created"}, m["127"].(serdes.Map)["25"])
+	assert.Equal(t, "00", m["39"])
+
+	err := serdes.Set(m, "2.1", "value")
+	assert.True(t, errors.Is(err, serdes.ErrNotMap))
+
+	err = serdes.Set(m, "", "value")
+	assert.True(t, errors.Is(err, serdes.ErrEmptyPath))
+}
+
+func Test_Path_Set_Error_Unchanged(t *testing.T) {
+	m := newPathMap()
+
+	tests := []struct {
+		path    string
+		wantErr error
+	}{
+		{path: "61.1..2", wantErr: serdes.ErrEmptyPath},
+		{path: "61.1.", wantErr: serdes.ErrEmptyPath},
+		{path: "127.25..1", wantErr: serdes.ErrEmptyPath},
+		{path: "48.TCC.1.2", wantErr: serdes.ErrNotMap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := serdes.Set(m, tt.path, "value")
+			assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+			assert.Equal(t, newPathMap(), m)
+		})
+	}
+}
+
+func Test_Path_Delete(t *testing.T) {
+	m := newPathMap()
+
+	assert.NoError(t, serdes.Delete(m, "55.9F26"))
+	assert.Equal(t, serdes.Map{}, m["55"])
+
+	err := serdes.Delete(m, "55.9F26")
+	assert.True(t, errors.Is(err, serdes.ErrPathNotFound))
+
+	err = serdes.Delete(m, "48.TCC.1")
+	assert.True(t, errors.Is(err, serdes.ErrNotMap))
+}
+
+func Test_Path_Walk(t *testing.T) {
+	var paths []string
+	err := serdes.Walk(newPathMap(), func(path string, value serdes.Value) error {
+		paths = append(paths, path)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"127", "127.3", "127.3.2", "2", "48", "48.TCC", "55", "55.9F26"}, paths)
+}
+
+func Test_Path_Walk_Skip_And_Stop(t *testing.T) {
+	var paths []string
+	err := serdes.Walk(newPathMap(), func(path string, value serdes.Value) error {
+		paths = append(paths, path)
+		if path == "127" {
+			return serdes.SkipMap
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"127", "2", "48", "48.TCC", "55", "55.9F26"}, paths)
+
+	stop := errors.New("stop")
+	err = serdes.Walk(newPathMap(), func(path string, value serdes.Value) error {
+		if path == "48.TCC" {
+			return stop
+		}
+		return nil
+	})
+
+	assert.Equal(t, stop, err)
+}
@@ -0,0 +1,20 @@
+package serdes
+
+// Sizer is implemented by the serdes that compute the size of the encoded value without encoding it, so callers can
+// preallocate buffers and check frame limits before packing a message.
+type Sizer interface {
+	Size(value Value) (int, error)
+}
+
+// Size returns the size of the encoded value, the serdes that don't implement Sizer are encoded with Serialize.
+func Size(s Serializer, value Value) (int, error) {
+	if sizer, ok := s.(Sizer); ok {
+		return sizer.Size(value)
+	}
+
+	data, err := s.Serialize(value)
+	if err != nil {
+		return 0, err
+	}
+	return data.Len(), nil
+}
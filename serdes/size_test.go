@@ -0,0 +1,24 @@
+package serdes_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Size_Fallback(t *testing.T) {
+	ser := &serdes.Mock{}
+	ser.On("Serialize", "value").Return(bytes.NewBufferString("data"), nil)
+	ser.On("Serialize", "invalid").Return((*bytes.Buffer)(nil), errors.New("invalid value"))
+
+	size, err := serdes.Size(ser, "value")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, size)
+
+	_, err = serdes.Size(ser, "invalid")
+	assert.EqualError(t, err, "invalid value")
+}
@@ -0,0 +1,197 @@
+package spec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// BuildFunc creates the serdes defined by the node, the builder must be used to build the nested nodes.
+type BuildFunc func(node *Node, builder Builder) (serdes.Serdes, error)
+
+// ExportFunc creates the node that defines the serdes, the exporter must be used to export the nested serdes.
+type ExportFunc func(s serdes.Serdes, exporter Exporter) (*Node, error)
+
+// Registry holds the functions to build and export every serdes by its Name(), the names are case insensitive.
+type Registry struct {
+	mu        sync.RWMutex
+	builders  map[string]BuildFunc
+	exporters map[string]ExportFunc
+}
+
+// DefaultRegistry is used by Load, Save, Export and Node.Build, it has all the types of the types package.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns a registry with all the types of the types package.
+func NewRegistry() *Registry {
+	registry := &Registry{builders: map[string]BuildFunc{}, exporters: map[string]ExportFunc{}}
+	registerTypes(registry)
+	return registry
+}
+
+// Register adds a custom serdes to the default registry.
+func Register(name string, build BuildFunc, export ExportFunc) {
+	DefaultRegistry.Register(name, build, export)
+}
+
+// Export creates the node that defines the serdes tree with the default registry.
+func Export(s serdes.Serdes) (*Node, error) {
+	return DefaultRegistry.Export(s)
+}
+
+// Register adds the functions to build and export the serdes with the name, replacing the previous ones.
+func (registry *Registry) Register(name string, build BuildFunc, export ExportFunc) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	key := strings.ToLower(name)
+	registry.builders[key] = build
+	registry.exporters[key] = export
+}
+
+// Build creates the serdes tree defined by the node.
+func (registry *Registry) Build(node *Node) (serdes.Serdes, error) {
+	return Builder{registry: registry}.build(node)
+}
+
+// Export creates the node that defines the serdes tree.
+func (registry *Registry) Export(s serdes.Serdes) (*Node, error) {
+	return Exporter{registry: registry}.export(s)
+}
+
+func (registry *Registry) builder(name string) (BuildFunc, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	build, ok := registry.builders[strings.ToLower(name)]
+	return build, ok && build != nil
+}
+
+func (registry *Registry) exporter(name string) (ExportFunc, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	export, ok := registry.exporters[strings.ToLower(name)]
+	return export, ok && export != nil
+}
+
+// Builder builds nodes keeping the path of the node being built, to report it in errors.
+type Builder struct {
+	registry *Registry
+	path     string
+}
+
+// Path returns the path of the node being built.
+func (builder Builder) Path() string {
+	return builder.path
+}
+
+// Child builds a nested node.
+func (builder Builder) Child(key string, node *Node) (serdes.Serdes, error) {
+	child := Builder{registry: builder.registry, path: serdes.JoinPath(builder.path, key)}
+	return child.build(node)
+}
+
+// Items builds the nodes of a list of fields, using the node names as field names.
+func (builder Builder) Items(nodes []*Node) ([]types.Field, error) {
+	items := make([]types.Field, 0, len(nodes))
+	for index, node := range nodes {
+		key := strconv.Itoa(index)
+		if node != nil && node.Name != "" {
+			key = node.Name
+		}
+
+		itemSerdes, err := builder.Child(key, node)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, types.Field{Name: node.Name, SerDes: itemSerdes})
+	}
+	return items, nil
+}
+
+// Error returns an error with the path of the node being built.
+func (builder Builder) Error(message string, cause error) error {
+	return Error{Message: message, Path: builder.path, Cause: cause}
+}
+
+func (builder Builder) build(node *Node) (serdes.Serdes, error) {
+	if node == nil {
+		return nil, builder.Error("missing definition", nil)
+	}
+
+	build, ok := builder.registry.builder(node.Type)
+	if !ok {
+		return nil, builder.Error(fmt.Sprintf("unknown type %q", node.Type), nil)
+	}
+
+	return build(node, builder)
+}
+
+// Exporter exports serdes keeping the path of the serdes being exported, to report it in errors.
+type Exporter struct {
+	registry *Registry
+	path     string
+}
+
+// Path returns the path of the serdes being exported.
+func (exporter Exporter) Path() string {
+	return exporter.path
+}
+
+// Child exports a nested serdes.
+func (exporter Exporter) Child(key string, s serdes.Serdes) (*Node, error) {
+	child := Exporter{registry: exporter.registry, path: serdes.JoinPath(exporter.path, key)}
+	return child.export(s)
+}
+
+// Items exports a list of fields, setting the field names as node names.
+func (exporter Exporter) Items(items []types.Field) ([]*Node, error) {
+	nodes := make([]*Node, 0, len(items))
+	for index, item := range items {
+		key := item.Name
+		if key == "" {
+			key = strconv.Itoa(index)
+		}
+
+		node, err := exporter.Child(key, item.SerDes)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Name = item.Name
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// Error returns an error with the path of the serdes being exported.
+func (exporter Exporter) Error(message string, cause error) error {
+	return Error{Message: message, Path: exporter.path, Cause: cause}
+}
+
+func (exporter Exporter) export(s serdes.Serdes) (*Node, error) {
+	if s == nil {
+		return nil, exporter.Error("missing serdes", nil)
+	}
+
+	export, ok := exporter.registry.exporter(s.Name())
+	if !ok {
+		return nil, exporter.Error(fmt.Sprintf("unknown type %q", s.Name()), nil)
+	}
+
+	node, err := export(s, exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	if node.Type == "" {
+		node.Type = s.Name()
+	}
+	return node, nil
+}
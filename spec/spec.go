@@ -0,0 +1,156 @@
+// Package spec builds serdes trees from declarative JSON or YAML definitions, and exports serdes trees to them.
+package spec
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Node is the declarative definition of a serdes, the Type is the Name() of the serdes and the other attributes are
+// used according to it, example:
+//
+//	{"type": "var_length", "desc": "PAN", "length": {"type": "byte"}, "data": {"type": "bcd"}}
+//
+// The attributes of custom serdes that don't fit in the common ones can be set in Params.
+type Node struct {
+	Type      string                 `json:"type" yaml:"type"`
+	Name      string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Desc      string                 `json:"desc,omitempty" yaml:"desc,omitempty"`
+	Mask      string                 `json:"mask,omitempty" yaml:"mask,omitempty"`
+	NumDigits int                    `json:"num_digits,omitempty" yaml:"num_digits,omitempty"`
+	NumBytes  int                    `json:"num_bytes,omitempty" yaml:"num_bytes,omitempty"`
+	NotPadded bool                   `json:"not_padded,omitempty" yaml:"not_padded,omitempty"`
+	Order     string                 `json:"order,omitempty" yaml:"order,omitempty"`
+	SizeLen   int                    `json:"size_len,omitempty" yaml:"size_len,omitempty"`
+	SizeTag   int                    `json:"size_tag,omitempty" yaml:"size_tag,omitempty"`
+	Ascii     bool                   `json:"ascii,omitempty" yaml:"ascii,omitempty"`
+	Bitmap    *BitmapNode            `json:"bitmap,omitempty" yaml:"bitmap,omitempty"`
+	Length    *Node                  `json:"length,omitempty" yaml:"length,omitempty"`
+	Data      *Node                  `json:"data,omitempty" yaml:"data,omitempty"`
+	Fields    map[string]*Node       `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Items     []*Node                `json:"items,omitempty" yaml:"items,omitempty"`
+	Params    map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+type BitmapNode struct {
+	BlockSize int  `json:"block_size" yaml:"block_size"`
+	NumBits   int  `json:"num_bits" yaml:"num_bits"`
+	Hex       bool `json:"hex,omitempty" yaml:"hex,omitempty"`
+}
+
+type Format int
+
+const (
+	JSON Format = iota
+	YAML
+)
+
+// Error is the error of the package, see serdes.Error.
+type Error = serdes.Error
+
+// FormatOf returns the format of a spec file by its extension, .yaml and .yml files are YAML, any other is JSON.
+func FormatOf(filename string) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return YAML
+	}
+	return JSON
+}
+
+// Parse decodes a JSON spec definition.
+func Parse(data []byte) (*Node, error) {
+	return ParseFormat(data, JSON)
+}
+
+// ParseYAML decodes a YAML spec definition.
+func ParseYAML(data []byte) (*Node, error) {
+	return ParseFormat(data, YAML)
+}
+
+func ParseFormat(data []byte, format Format) (*Node, error) {
+	node := new(Node)
+
+	var err error
+	switch format {
+	case YAML:
+		err = yaml.Unmarshal(data, node)
+	default:
+		err = json.Unmarshal(data, node)
+	}
+
+	if err != nil {
+		return nil, Error{Message: "error decoding spec", Cause: err}
+	}
+	return node, nil
+}
+
+// Encode returns the node encoded in the format.
+func (node *Node) Encode(format Format) ([]byte, error) {
+	var data []byte
+	var err error
+	switch format {
+	case YAML:
+		data, err = yaml.Marshal(node)
+	default:
+		data, err = json.MarshalIndent(node, "", "  ")
+	}
+
+	if err != nil {
+		return nil, Error{Message: "error encoding spec", Cause: err}
+	}
+	return data, nil
+}
+
+// Load reads the spec definition from a file and builds its serdes tree with the default registry.
+func Load(filename string) (serdes.Serdes, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, Error{Message: "error reading spec file", Cause: err}
+	}
+
+	node, err := ParseFormat(data, FormatOf(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	return node.Build()
+}
+
+// Save exports the serdes tree with the default registry and writes it into a file.
+func Save(filename string, s serdes.Serdes) error {
+	node, err := Export(s)
+	if err != nil {
+		return err
+	}
+
+	data, err := node.Encode(FormatOf(filename))
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return Error{Message: "error writing spec file", Cause: err}
+	}
+	return nil
+}
+
+// Build creates the serdes tree defined by the node with the default registry.
+func (node *Node) Build() (serdes.Serdes, error) {
+	return DefaultRegistry.Build(node)
+}
+
+func sortedKeys(fields map[string]*Node) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
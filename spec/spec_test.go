@@ -0,0 +1,255 @@
+package spec_test
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/spec"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse_Build_Success(t *testing.T) {
+	node, err := spec.Parse([]byte(`{
+		"type": "list",
+		"items": [
+			{"name": "mti", "type": "ebcdic_numeric", "num_digits": 4},
+			{
+				"type": "bitmapped",
+				"bitmap": {"block_size": 64, "num_bits": 128},
+				"fields": {
+					"2": {"type": "var_length", "desc": "PAN", "length": {"type": "byte"}, "data": {"type": "bcd", "mask": "pan"}},
+					"3": {"type": "bcd", "num_digits": 6, "not_padded": true},
+					"43": {"type": "ebcdic", "num_digits": 40},
+					"52": {"type": "raw", "num_bytes": 8},
+					"55": {"type": "var_length", "length": {"type": "word", "order": "little"}, "data": {"type": "bertlv", "items": [{"name": "9f26", "type": "raw"}]}},
+					"48": {"type": "var_length", "length": {"type": "ebcdic_numeric", "num_digits": 3}, "data": {"type": "tlv", "size_tag": 2, "size_len": 2, "items": [{"name": "21", "type": "ebcdic"}]}},
+					"62": {"type": "var_length", "length": {"type": "ascii_numeric", "num_digits": 3}, "data": {"type": "tlv", "size_tag": 2, "size_len": 3, "ascii": true, "items": [{"name": "01", "type": "ascii"}]}}
+				}
+			}
+		]
+	}`))
+	assert.NoError(t, err)
+
+	built, err := node.Build()
+	assert.NoError(t, err)
+
+	expected := types.List{
+		Items: []types.Field{
+			{Name: "mti", SerDes: types.EbcdicNumeric{NumDigits: 4}},
+			{Name: "", SerDes: types.BitMapped{
+				Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128},
+				Mapping: map[int]serdes.Serdes{
+					2:  types.VarLength{Desc: "PAN", Length: types.Byte{}, Data: types.Bcd{Mask: types.MaskPAN}},
+					3:  types.Bcd{NumDigits: 6, NotPadded: true},
+					43: types.Ebcdic{NumDigits: 40},
+					52: types.Raw{NumBytes: 8},
+					55: types.VarLength{Length: types.Word{Order: binary.LittleEndian}, Data: types.BerTLV{
+						Items: []types.Field{{Name: "9f26", SerDes: types.Raw{}}},
+					}},
+					48: types.VarLength{Length: types.EbcdicNumeric{NumDigits: 3}, Data: types.TLV{
+						SizeTag: 2, SizeLen: 2, Items: []types.Field{{Name: "21", SerDes: types.Ebcdic{}}},
+					}},
+					62: types.VarLength{Length: types.AsciiNumeric{NumDigits: 3}, Data: types.TLV{
+						SizeTag: 2, SizeLen: 3, Ascii: true, Items: []types.Field{{Name: "01", SerDes: types.Ascii{}}},
+					}},
+				},
+			}},
+		},
+	}
+	assert.Equal(t, expected, built)
+}
+
+func Test_Parse_Build_Errors(t *testing.T) {
+	_, err := spec.Parse([]byte(`{invalid`))
+	assert.Error(t, err)
+
+	tests := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{name: "unknown type", spec: `{"type": "unknown"}`, want: `unknown type "unknown"`},
+		{name: "missing bitmap", spec: `{"type": "bitmapped"}`, want: "missing bitmap definition"},
+		{
+			name: "invalid bit number",
+			spec: `{"type": "bitmapped", "bitmap": {"block_size": 64, "num_bits": 64}, "fields": {"x": {"type": "byte"}}}`,
+			want: `invalid bit number: path: x. -> strconv.Atoi: parsing "x": invalid syntax`,
+		},
+		{
+			name: "missing var length data",
+			spec: `{"type": "list", "items": [{"name": "2", "type": "var_length", "length": {"type": "byte"}}]}`,
+			want: "missing definition: path: 2.data.",
+		},
+		{name: "invalid byte order", spec: `{"type": "word", "order": "middle"}`, want: `unknown byte order "middle"`},
+		{name: "invalid mask", spec: `{"type": "ascii", "mask": "some"}`, want: `unknown mask "some"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := spec.Parse([]byte(tt.spec))
+			assert.NoError(t, err)
+
+			_, err = node.Build()
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func Test_Load_Error(t *testing.T) {
+	_, err := spec.Load("testdata/not_found.json")
+	assert.Error(t, err)
+}
+
+func Test_ParseYAML_Build_Success(t *testing.T) {
+	node, err := spec.ParseYAML([]byte(`
+type: list
+items:
+  - name: mti
+    type: ebcdic_numeric
+    num_digits: 4
+  - type: bitMapped
+    bitmap:
+      block_size: 64
+      num_bits: 128
+    fields:
+      2:
+        type: var_length
+        desc: PAN
+        length: {type: byte}
+        data: {type: bcd}
+      11: {type: bcd, num_digits: 6, not_padded: true}
+`))
+	assert.NoError(t, err)
+
+	built, err := node.Build()
+	assert.NoError(t, err)
+
+	expected := types.List{
+		Items: []types.Field{
+			{Name: "mti", SerDes: types.EbcdicNumeric{NumDigits: 4}},
+			{Name: "", SerDes: types.BitMapped{
+				Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128},
+				Mapping: map[int]serdes.Serdes{
+					2:  types.VarLength{Desc: "PAN", Length: types.Byte{}, Data: types.Bcd{}},
+					11: types.Bcd{NumDigits: 6, NotPadded: true},
+				},
+			}},
+		},
+	}
+	assert.Equal(t, expected, built)
+}
+
+func Test_Export_Roundtrip(t *testing.T) {
+	original := types.List{
+		Desc: "message",
+		Items: []types.Field{
+			{Name: "mti", SerDes: types.EbcdicNumeric{NumDigits: 4}},
+			{Name: "", SerDes: types.BitMapped{
+				Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128},
+				Mapping: map[int]serdes.Serdes{
+					2:  types.VarLength{Desc: "PAN", Length: types.Byte{}, Data: types.Bcd{Mask: types.MaskPAN}},
+					3:  types.Bcd{NumDigits: 6, NotPadded: true},
+					43: types.Ebcdic{NumDigits: 40},
+					52: types.Raw{NumBytes: 8},
+					55: types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: types.BerTLV{
+						SizeLen: 1, Items: []types.Field{{Name: "9f26", SerDes: types.Raw{}}},
+					}},
+					48: types.VarLength{Length: types.EbcdicNumeric{NumDigits: 3}, Data: types.TLV{
+						SizeTag: 2, SizeLen: 2, Items: []types.Field{{Name: "21", SerDes: types.Ebcdic{}}},
+					}},
+					62: types.VarLength{Length: types.AsciiNumeric{NumDigits: 3}, Data: types.TLV{
+						SizeTag: 2, SizeLen: 3, Ascii: true, Items: []types.Field{{Name: "01", SerDes: types.Ascii{}}},
+					}},
+				},
+			}},
+		},
+	}
+
+	for _, filename := range []string{"spec.json", "spec.yaml"} {
+		t.Run(filename, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), filename)
+			assert.NoError(t, spec.Save(path, original))
+
+			loaded, err := spec.Load(path)
+			assert.NoError(t, err)
+			assert.Equal(t, original, loaded)
+		})
+	}
+}
+
+func Test_Export_Unknown_Type(t *testing.T) {
+	_, err := spec.Export(types.List{Items: []types.Field{{Name: "1", SerDes: &serdes.Mock{}}}})
+	assert.EqualError(t, err, `unknown type "mock": path: 1.`)
+}
+
+type bitMappedCopy struct {
+	types.BitMapped
+}
+
+func (bitMappedCopy) Name() string {
+	return "bitMapped"
+}
+
+func Test_Export_Unexpected_Serdes(t *testing.T) {
+	plan, err := types.Compile(types.BitMapped{
+		Bitmap:  types.Bitmap{BlockSize: 64, NumBits: 64},
+		Mapping: map[int]serdes.Serdes{3: types.Bcd{NumDigits: 6}},
+	})
+	assert.NoError(t, err)
+
+	_, err = spec.Export(types.List{Items: []types.Field{{Name: "message", SerDes: plan}}})
+	assert.EqualError(t, err, `unexpected serdes *types.Plan for type "bitMapped": path: message.`)
+
+	_, err = spec.Export(types.VarLength{Length: types.Byte{}, Data: bitMappedCopy{}})
+	assert.EqualError(t, err, `unexpected serdes spec_test.bitMappedCopy for type "bitMapped": path: data.`)
+}
+
+type fixedText struct {
+	types.Ebcdic
+	Filler string
+}
+
+func (fixedText) Name() string {
+	return "fixed_text"
+}
+
+func Test_Registry_Custom_Serdes(t *testing.T) {
+	registry := spec.NewRegistry()
+	registry.Register("fixed_text",
+		func(node *spec.Node, builder spec.Builder) (serdes.Serdes, error) {
+			filler, ok := node.Params["filler"].(string)
+			if !ok {
+				return nil, builder.Error("filler param is required", nil)
+			}
+			return fixedText{Ebcdic: types.Ebcdic{NumDigits: node.NumDigits}, Filler: filler}, nil
+		},
+		func(s serdes.Serdes, exporter spec.Exporter) (*spec.Node, error) {
+			custom := s.(fixedText)
+			return &spec.Node{NumDigits: custom.NumDigits, Params: map[string]interface{}{"filler": custom.Filler}}, nil
+		},
+	)
+
+	node, err := spec.Parse([]byte(`{"type": "list", "items": [{"name": "1", "type": "fixed_text", "num_digits": 3, "params": {"filler": "*"}}]}`))
+	assert.NoError(t, err)
+
+	built, err := registry.Build(node)
+	assert.NoError(t, err)
+
+	expected := types.List{Items: []types.Field{{Name: "1", SerDes: fixedText{Ebcdic: types.Ebcdic{NumDigits: 3}, Filler: "*"}}}}
+	assert.Equal(t, expected, built)
+
+	exported, err := registry.Export(built)
+	assert.NoError(t, err)
+	assert.Equal(t, node, exported)
+
+	_, err = node.Build()
+	assert.EqualError(t, err, `unknown type "fixed_text": path: 1.`)
+
+	node.Items[0].Params = nil
+	_, err = registry.Build(node)
+	assert.EqualError(t, err, "filler param is required: path: 1.")
+}
@@ -0,0 +1,337 @@
+package spec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+const _ebcdicNumericType = "ebcdic_numeric"
+
+func registerTypes(registry *Registry) {
+	registry.Register(types.Bcd{}.Name(), buildBcd, exportBcd)
+	registry.Register(types.Ebcdic{}.Name(), buildEbcdic, exportEbcdic)
+	// EbcdicNumeric has the name of Ebcdic, exportEbcdic sets the type of the numeric nodes.
+	registry.Register(_ebcdicNumericType, buildEbcdicNumeric, exportEbcdic)
+	registry.Register(types.Ascii{}.Name(), buildAscii, exportAscii)
+	registry.Register(types.AsciiNumeric{}.Name(), buildAsciiNumeric, exportAsciiNumeric)
+	registry.Register(types.Raw{}.Name(), buildRaw, exportRaw)
+	registry.Register(types.Byte{}.Name(), buildByte, exportByte)
+	registry.Register(types.Word{}.Name(), buildWord, exportWord)
+	registry.Register(types.VarLength{}.Name(), buildVarLength, exportVarLength)
+	registry.Register(types.List{}.Name(), buildList, exportList)
+	registry.Register(types.TLV{}.Name(), buildTLV, exportTLV)
+	registry.Register(types.BerTLV{}.Name(), buildBerTLV, exportBerTLV)
+	registry.Register(types.BitMapped{}.Name(), buildBitMapped, exportBitMapped)
+}
+
+func buildBcd(node *Node, builder Builder) (serdes.Serdes, error) {
+	mask, err := buildMask(node, builder)
+	if err != nil {
+		return nil, err
+	}
+	return types.Bcd{Desc: types.Desc(node.Desc), Mask: mask, NumDigits: node.NumDigits, NotPadded: node.NotPadded}, nil
+}
+
+func exportBcd(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	bcd, ok := s.(types.Bcd)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	return &Node{Desc: string(bcd.Desc), Mask: string(bcd.Mask), NumDigits: bcd.NumDigits, NotPadded: bcd.NotPadded}, nil
+}
+
+func buildEbcdic(node *Node, builder Builder) (serdes.Serdes, error) {
+	mask, err := buildMask(node, builder)
+	if err != nil {
+		return nil, err
+	}
+	return types.Ebcdic{Desc: types.Desc(node.Desc), Mask: mask, NumDigits: node.NumDigits}, nil
+}
+
+func exportEbcdic(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	switch ebcdic := s.(type) {
+	case types.Ebcdic:
+		return &Node{Desc: string(ebcdic.Desc), Mask: string(ebcdic.Mask), NumDigits: ebcdic.NumDigits}, nil
+	case types.EbcdicNumeric:
+		return &Node{
+			Type: _ebcdicNumericType, Desc: string(ebcdic.Desc), Mask: string(ebcdic.Mask), NumDigits: ebcdic.NumDigits,
+		}, nil
+	}
+	return nil, unexpectedSerdes(s, exporter)
+}
+
+func buildEbcdicNumeric(node *Node, builder Builder) (serdes.Serdes, error) {
+	mask, err := buildMask(node, builder)
+	if err != nil {
+		return nil, err
+	}
+	return types.EbcdicNumeric{Desc: types.Desc(node.Desc), Mask: mask, NumDigits: node.NumDigits}, nil
+}
+
+func buildAscii(node *Node, builder Builder) (serdes.Serdes, error) {
+	mask, err := buildMask(node, builder)
+	if err != nil {
+		return nil, err
+	}
+	return types.Ascii{Desc: types.Desc(node.Desc), Mask: mask, NumDigits: node.NumDigits}, nil
+}
+
+func exportAscii(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	ascii, ok := s.(types.Ascii)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	return &Node{Desc: string(ascii.Desc), Mask: string(ascii.Mask), NumDigits: ascii.NumDigits}, nil
+}
+
+func buildAsciiNumeric(node *Node, builder Builder) (serdes.Serdes, error) {
+	mask, err := buildMask(node, builder)
+	if err != nil {
+		return nil, err
+	}
+	return types.AsciiNumeric{Desc: types.Desc(node.Desc), Mask: mask, NumDigits: node.NumDigits}, nil
+}
+
+func exportAsciiNumeric(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	ascii, ok := s.(types.AsciiNumeric)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	return &Node{Desc: string(ascii.Desc), Mask: string(ascii.Mask), NumDigits: ascii.NumDigits}, nil
+}
+
+func buildRaw(node *Node, builder Builder) (serdes.Serdes, error) {
+	mask, err := buildMask(node, builder)
+	if err != nil {
+		return nil, err
+	}
+	return types.Raw{Desc: types.Desc(node.Desc), Mask: mask, NumBytes: node.NumBytes}, nil
+}
+
+func exportRaw(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	raw, ok := s.(types.Raw)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	return &Node{Desc: string(raw.Desc), Mask: string(raw.Mask), NumBytes: raw.NumBytes}, nil
+}
+
+func buildMask(node *Node, builder Builder) (types.Mask, error) {
+	mask := types.Mask(node.Mask)
+	switch mask {
+	case types.NoMask, types.MaskPAN, types.MaskAll:
+		return mask, nil
+	}
+	return "", builder.Error(fmt.Sprintf("unknown mask %q", node.Mask), nil)
+}
+
+func buildByte(node *Node, builder Builder) (serdes.Serdes, error) {
+	mask, err := buildMask(node, builder)
+	if err != nil {
+		return nil, err
+	}
+	return types.Byte{Desc: types.Desc(node.Desc), Mask: mask}, nil
+}
+
+func exportByte(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	byteSerdes, ok := s.(types.Byte)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	return &Node{Desc: string(byteSerdes.Desc), Mask: string(byteSerdes.Mask)}, nil
+}
+
+func buildWord(node *Node, builder Builder) (serdes.Serdes, error) {
+	var order binary.ByteOrder
+	switch node.Order {
+	case "", "big":
+		order = binary.BigEndian
+	case "little":
+		order = binary.LittleEndian
+	default:
+		return nil, builder.Error(fmt.Sprintf("unknown byte order %q", node.Order), nil)
+	}
+
+	mask, err := buildMask(node, builder)
+	if err != nil {
+		return nil, err
+	}
+	return types.Word{Desc: types.Desc(node.Desc), Mask: mask, Order: order}, nil
+}
+
+func exportWord(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	word, ok := s.(types.Word)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	node := &Node{Desc: string(word.Desc), Mask: string(word.Mask)}
+	switch word.Order {
+	case binary.BigEndian:
+		node.Order = "big"
+	case binary.LittleEndian:
+		node.Order = "little"
+	default:
+		return nil, exporter.Error("unknown byte order", nil)
+	}
+	return node, nil
+}
+
+func buildVarLength(node *Node, builder Builder) (serdes.Serdes, error) {
+	length, err := builder.Child("length", node.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := builder.Child("data", node.Data)
+	if err != nil {
+		return nil, err
+	}
+	return types.VarLength{Desc: types.Desc(node.Desc), Length: length, Data: data}, nil
+}
+
+func exportVarLength(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	varLen, ok := s.(types.VarLength)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	length, err := exporter.Child("length", varLen.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := exporter.Child("data", varLen.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{Desc: string(varLen.Desc), Length: length, Data: data}, nil
+}
+
+func buildList(node *Node, builder Builder) (serdes.Serdes, error) {
+	items, err := builder.Items(node.Items)
+	if err != nil {
+		return nil, err
+	}
+	return types.List{Desc: types.Desc(node.Desc), Items: items}, nil
+}
+
+func exportList(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	list, ok := s.(types.List)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	items, err := exporter.Items(list.Items)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{Desc: string(list.Desc), Items: items}, nil
+}
+
+func buildTLV(node *Node, builder Builder) (serdes.Serdes, error) {
+	items, err := builder.Items(node.Items)
+	if err != nil {
+		return nil, err
+	}
+	return types.TLV{
+		Desc: types.Desc(node.Desc), SizeLen: node.SizeLen, SizeTag: node.SizeTag, Ascii: node.Ascii, Items: items,
+	}, nil
+}
+
+func exportTLV(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	tlv, ok := s.(types.TLV)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	items, err := exporter.Items(tlv.Items)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{Desc: string(tlv.Desc), SizeLen: tlv.SizeLen, SizeTag: tlv.SizeTag, Ascii: tlv.Ascii, Items: items}, nil
+}
+
+func buildBerTLV(node *Node, builder Builder) (serdes.Serdes, error) {
+	items, err := builder.Items(node.Items)
+	if err != nil {
+		return nil, err
+	}
+	return types.BerTLV{Desc: types.Desc(node.Desc), SizeLen: node.SizeLen, Items: items}, nil
+}
+
+func exportBerTLV(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	tlv, ok := s.(types.BerTLV)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	items, err := exporter.Items(tlv.Items)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{Desc: string(tlv.Desc), SizeLen: tlv.SizeLen, Items: items}, nil
+}
+
+func buildBitMapped(node *Node, builder Builder) (serdes.Serdes, error) {
+	if node.Bitmap == nil {
+		return nil, builder.Error("missing bitmap definition", nil)
+	}
+
+	bitMapped := types.BitMapped{
+		Desc:    types.Desc(node.Desc),
+		Bitmap:  types.Bitmap{BlockSize: node.Bitmap.BlockSize, NumBits: node.Bitmap.NumBits, Hex: node.Bitmap.Hex},
+		Mapping: make(map[int]serdes.Serdes, len(node.Fields)),
+	}
+
+	for _, key := range sortedKeys(node.Fields) {
+		bitNumber, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, Error{Message: "invalid bit number", Path: serdes.JoinPath(builder.Path(), key), Cause: err}
+		}
+
+		fieldSerdes, err := builder.Child(key, node.Fields[key])
+		if err != nil {
+			return nil, err
+		}
+
+		bitMapped.Mapping[bitNumber] = fieldSerdes
+	}
+
+	return bitMapped, nil
+}
+
+func exportBitMapped(s serdes.Serdes, exporter Exporter) (*Node, error) {
+	bitMapped, ok := s.(types.BitMapped)
+	if !ok {
+		return nil, unexpectedSerdes(s, exporter)
+	}
+	node := &Node{
+		Desc:   string(bitMapped.Desc),
+		Bitmap: &BitmapNode{BlockSize: bitMapped.Bitmap.BlockSize, NumBits: bitMapped.Bitmap.NumBits, Hex: bitMapped.Bitmap.Hex},
+		Fields: make(map[string]*Node, len(bitMapped.Mapping)),
+	}
+
+	bits := make([]int, 0, len(bitMapped.Mapping))
+	for bit := range bitMapped.Mapping {
+		bits = append(bits, bit)
+	}
+	sort.Ints(bits)
+
+	for _, bit := range bits {
+		key := strconv.Itoa(bit)
+		field, err := exporter.Child(key, bitMapped.Mapping[bit])
+		if err != nil {
+			return nil, err
+		}
+		node.Fields[key] = field
+	}
+
+	return node, nil
+}
+
+// unexpectedSerdes is returned by the export functions when the serdes has the name of a type of the types package, but
+// not its type, like a types.Plan or a custom serdes.
+func unexpectedSerdes(s serdes.Serdes, exporter Exporter) error {
+	return exporter.Error(fmt.Sprintf("unexpected serdes %T for type %q", s, s.Name()), nil)
+}
@@ -0,0 +1,72 @@
+package specs
+
+import (
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// AmexGCAG returns the American Express Global Credit Authorization Guide spec, based on ISO 8583:1993 with an EBCDIC
+// MTI, a binary primary and secondary bitmap and EBCDIC fields with EBCDIC length prefixes.
+//
+// The data elements 22 (point of service data code) and 56 (original data elements) are decoded into maps with their
+// subfields. The ICC data of the data element 55 has the American Express positional layout, so it is kept as raw
+// bytes.
+func AmexGCAG() serdes.Serdes {
+	return message(en("Message type identifier", 4), types.Bitmap{BlockSize: 64, NumBits: 128}, amexFields())
+}
+
+func amexFields() map[int]serdes.Serdes {
+	return map[int]serdes.Serdes{
+		2:  ellvar("Primary account number", masked(varEbcdicNumeric, types.MaskPAN)),
+		3:  en("Processing code", 6),
+		4:  en("Amount, transaction", 12),
+		7:  en("Date and time, transmission", 10),
+		11: en("System trace audit number", 6),
+		12: en("Date and time, local transaction", 12),
+		13: en("Date, effective", 4),
+		14: en("Date, expiration", 4),
+		19: en("Country code, acquiring institution", 3),
+		22: amexPOSDataCode(),
+		24: en("Function code", 3),
+		25: en("Message reason code", 4),
+		26: en("Card acceptor business code", 4),
+		27: en("Approval code length", 1),
+		32: ellvar("Acquiring institution identification code", varEbcdicNumeric),
+		33: ellvar("Forwarding institution identification code", varEbcdicNumeric),
+		35: ellvar("Track 2 data", masked(varEbcdic, types.MaskAll)),
+		37: ebcdic("Retrieval reference number", 12),
+		38: ebcdic("Approval code", 6),
+		39: en("Action code", 3),
+		41: ebcdic("Card acceptor terminal identification", 8),
+		42: ebcdic("Card acceptor identification code", 15),
+		43: ellvar("Card acceptor name/location", varEbcdic),
+		45: ellvar("Track 1 data", masked(varEbcdic, types.MaskAll)),
+		47: elllvar("Additional data, national", varEbcdic),
+		48: elllvar("Additional data, private", varEbcdic),
+		49: en("Currency code, transaction", 3),
+		52: masked(b("Personal identification number data", 8), types.MaskAll),
+		53: ellvar("Security related control information", varEbcdic),
+		55: elllvar("Integrated circuit card system related data", varBinary),
+		56: ellvar("Original data elements", types.List{
+			Items: []types.Field{
+				{Name: "original_mti", SerDes: en("Original message type identifier", 4)},
+				{Name: "original_stan", SerDes: en("Original system trace audit number", 6)},
+				{Name: "original_local_date_time", SerDes: en("Original date and time, local transaction", 12)},
+				{Name: "original_acquiring_institution", SerDes: ellvar("Original acquiring institution identification code", varEbcdicNumeric)},
+			},
+		}),
+		60: elllvar("National use data", varEbcdic),
+		61: elllvar("National use data", varEbcdic),
+		62: elllvar("Private use data", varEbcdic),
+		63: elllvar("Private use data", varEbcdic),
+		96: elllvar("Key management data", varBinary),
+	}
+}
+
+func amexPOSDataCode() types.List {
+	list := types.List{Desc: "Point of service data code"}
+	for _, code := range posDataCodes {
+		list.Items = append(list.Items, types.Field{Name: code.name, SerDes: ebcdic(code.desc, 1)})
+	}
+	return list
+}
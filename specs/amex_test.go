@@ -0,0 +1,84 @@
+package specs_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AmexGCAG_Authorization_Request(t *testing.T) {
+	data, _ := hex.DecodeString(strings.Join([]string{
+		"f1f1f0f0",
+		"7030050000008000",
+		"f1f5" + "f3f7f1f2f3f4f5f6f7f8f9f0f1f2f3",
+		"f0f0f4f0f0f0",
+		"f0f0f0f0f0f0f0f0f2f5f0f0",
+		"f0f0f0f7f8f9",
+		"f2f6f1f0f1f9f1f2f0f0f0f0",
+		"f5f1f0f1f0f1f5f1f3f0f0c3",
+		"f1f0f0",
+		"f8f4f0",
+	}, ""))
+	message := serdes.Map{
+		specs.MTIField: "1100",
+		"2":            "371234567890123",
+		"3":            "004000",
+		"4":            "000000002500",
+		"11":           "000789",
+		"12":           "261019120000",
+		"22": serdes.Map{
+			"card_data_input_capability":           "5",
+			"cardholder_authentication_capability": "1",
+			"card_capture_capability":              "0",
+			"operating_environment":                "1",
+			"cardholder_present":                   "0",
+			"card_present":                         "1",
+			"card_data_input_mode":                 "5",
+			"cardholder_authentication_method":     "1",
+			"cardholder_authentication_entity":     "3",
+			"card_data_output_capability":          "0",
+			"terminal_output_capability":           "0",
+			"pin_capture_capability":               "C",
+		},
+		"24": "100",
+		"49": "840",
+	}
+
+	value, err := specs.AmexGCAG().Deserialize(bytes.NewBuffer(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.AmexGCAG().Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.Bytes())
+}
+
+func Test_AmexGCAG_Original_Data_Elements(t *testing.T) {
+	data, _ := hex.DecodeString(strings.Join([]string{
+		"f1f4f2f0",
+		"0000000000000100",
+		"f2f2" + "f1f1f0f0" + "f0f0f0f7f8f9" + "f2f6f1f0f1f9f1f2f0f0f0f0",
+	}, ""))
+	message := serdes.Map{
+		specs.MTIField: "1420",
+		"56": serdes.Map{
+			"original_mti":             "1100",
+			"original_stan":            "000789",
+			"original_local_date_time": "261019120000",
+		},
+	}
+
+	value, err := specs.AmexGCAG().Deserialize(bytes.NewBuffer(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.AmexGCAG().Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.Bytes())
+}
@@ -0,0 +1,38 @@
+package specs
+
+import (
+	"fmt"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// Discover returns the Discover Global Network spec, also used for Diners Club International, based on ISO 8583:1987
+// with ASCII data elements.
+//
+// The data element 48 is decoded into a map of its tags, with 2 digits ASCII tags and 3 digits ASCII lengths, and the
+// data element 55 into its EMV tags. The tags of the data element 48 depend on the message, so all of them are listed
+// as text and callers can replace the items they decode. The point of service data of the data element 61 is kept as
+// text.
+func Discover(encoding BitmapEncoding) serdes.Serdes {
+	return message(n("Message type identifier", 4), encoding.bitmap(128), discoverFields())
+}
+
+func discoverFields() map[int]serdes.Serdes {
+	fields := iso87Fields()
+	fields[48] = discoverAdditionalData()
+	fields[55] = lllvar("Integrated circuit card related data", types.BerTLV{Items: emvTags()})
+	fields[61] = lllvar("Point of service data", varText)
+	return fields
+}
+
+// discoverAdditionalData is the DE 48, its tags have 2 digits and their lengths 3 digits.
+func discoverAdditionalData() types.VarLength {
+	items := make([]types.Field, 0, 99)
+	for tag := 1; tag <= 99; tag++ {
+		name := fmt.Sprintf("%02d", tag)
+		items = append(items, types.Field{Name: name, SerDes: types.Ascii{Desc: types.Desc("Tag " + name)}})
+	}
+
+	return lllvar("Additional data", types.TLV{Desc: "Tags", SizeTag: 2, SizeLen: 3, Ascii: true, Items: items})
+}
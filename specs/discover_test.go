@@ -0,0 +1,53 @@
+package specs_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Discover_ICC_Data(t *testing.T) {
+	data := "0100" + "7020000000000200" +
+		"16" + "6011000990139424" +
+		"000000" +
+		"000000001500" +
+		"000321" +
+		"011" + "\x9f\x26\x08\xa1\xb2\xc3\xd4\xe5\xf6\x07\x18"
+	message := serdes.Map{
+		specs.MTIField: "0100",
+		"2":            "6011000990139424",
+		"3":            "000000",
+		"4":            "000000001500",
+		"11":           "000321",
+		"55":           serdes.Map{"9f26": "a1b2c3d4e5f60718"},
+	}
+
+	value, err := specs.Discover(specs.HexBitmap).Deserialize(bytes.NewBufferString(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.Discover(specs.HexBitmap).Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.String())
+}
+
+func Test_Discover_Additional_Data(t *testing.T) {
+	data := "0100" + "0000000000010000" +
+		"020" + "01005ABCDE" + "42005F0001"
+	message := serdes.Map{
+		specs.MTIField: "0100",
+		"48":           serdes.Map{"01": "ABCDE", "42": "F0001"},
+	}
+
+	value, err := specs.Discover(specs.HexBitmap).Deserialize(bytes.NewBufferString(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.Discover(specs.HexBitmap).Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.String())
+}
@@ -0,0 +1,146 @@
+package specs
+
+import (
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// ISO87ASCII returns the ISO 8583:1987 spec with ASCII data elements and a primary and secondary bitmap.
+//
+// The x+n amounts keep their credit or debit sign as the first character, and the binary data elements (52, 64, 65, 96
+// and 128) are raw bytes.
+func ISO87ASCII(encoding BitmapEncoding) serdes.Serdes {
+	return message(n("Message type indicator", 4), encoding.bitmap(128), iso87Fields())
+}
+
+func iso87Fields() map[int]serdes.Serdes {
+	return map[int]serdes.Serdes{
+		2:   llvar("Primary account number", masked(varNumeric, types.MaskPAN)),
+		3:   n("Processing code", 6),
+		4:   n("Amount, transaction", 12),
+		5:   n("Amount, settlement", 12),
+		6:   n("Amount, cardholder billing", 12),
+		7:   n("Transmission date and time", 10),
+		8:   n("Amount, cardholder billing fee", 8),
+		9:   n("Conversion rate, settlement", 8),
+		10:  n("Conversion rate, cardholder billing", 8),
+		11:  n("System trace audit number", 6),
+		12:  n("Time, local transaction", 6),
+		13:  n("Date, local transaction", 4),
+		14:  n("Date, expiration", 4),
+		15:  n("Date, settlement", 4),
+		16:  n("Date, conversion", 4),
+		17:  n("Date, capture", 4),
+		18:  n("Merchant type", 4),
+		19:  n("Acquiring institution country code", 3),
+		20:  n("PAN extended, country code", 3),
+		21:  n("Forwarding institution country code", 3),
+		22:  n("Point of service entry mode", 3),
+		23:  n("Application PAN sequence number", 3),
+		24:  n("Network international identifier", 3),
+		25:  n("Point of service condition code", 2),
+		26:  n("Point of service capture code", 2),
+		27:  n("Authorizing identification response length", 1),
+		28:  an("Amount, transaction fee", 9),
+		29:  an("Amount, settlement fee", 9),
+		30:  an("Amount, transaction processing fee", 9),
+		31:  an("Amount, settlement processing fee", 9),
+		32:  llvar("Acquiring institution identification code", varNumeric),
+		33:  llvar("Forwarding institution identification code", varNumeric),
+		34:  llvar("Primary account number, extended", masked(varText, types.MaskPAN)),
+		35:  llvar("Track 2 data", masked(varText, types.MaskAll)),
+		36:  lllvar("Track 3 data", masked(varText, types.MaskAll)),
+		37:  an("Retrieval reference number", 12),
+		38:  an("Authorization identification response", 6),
+		39:  an("Response code", 2),
+		40:  an("Service restriction code", 3),
+		41:  an("Card acceptor terminal identification", 8),
+		42:  an("Card acceptor identification code", 15),
+		43:  an("Card acceptor name/location", 40),
+		44:  llvar("Additional response data", varText),
+		45:  llvar("Track 1 data", masked(varText, types.MaskAll)),
+		46:  lllvar("Additional data, ISO", varText),
+		47:  lllvar("Additional data, national", varText),
+		48:  lllvar("Additional data, private", varText),
+		49:  an("Currency code, transaction", 3),
+		50:  an("Currency code, settlement", 3),
+		51:  an("Currency code, cardholder billing", 3),
+		52:  masked(b("Personal identification number data", 8), types.MaskAll),
+		53:  n("Security related control information", 16),
+		54:  lllvar("Additional amounts", varText),
+		55:  lllvar("Reserved ISO", varText),
+		56:  lllvar("Reserved ISO", varText),
+		57:  lllvar("Reserved national", varText),
+		58:  lllvar("Reserved national", varText),
+		59:  lllvar("Reserved national", varText),
+		60:  lllvar("Reserved national", varText),
+		61:  lllvar("Reserved private", varText),
+		62:  lllvar("Reserved private", varText),
+		63:  lllvar("Reserved private", varText),
+		64:  b("Message authentication code", 8),
+		65:  b("Bitmap, extended", 1),
+		66:  n("Settlement code", 1),
+		67:  n("Extended payment code", 2),
+		68:  n("Receiving institution country code", 3),
+		69:  n("Settlement institution country code", 3),
+		70:  n("Network management information code", 3),
+		71:  n("Message number", 4),
+		72:  n("Message number, last", 4),
+		73:  n("Date, action", 6),
+		74:  n("Credits, number", 10),
+		75:  n("Credits, reversal number", 10),
+		76:  n("Debits, number", 10),
+		77:  n("Debits, reversal number", 10),
+		78:  n("Transfer, number", 10),
+		79:  n("Transfer, reversal number", 10),
+		80:  n("Inquiries, number", 10),
+		81:  n("Authorizations, number", 10),
+		82:  n("Credits, processing fee amount", 12),
+		83:  n("Credits, transaction fee amount", 12),
+		84:  n("Debits, processing fee amount", 12),
+		85:  n("Debits, transaction fee amount", 12),
+		86:  n("Credits, amount", 16),
+		87:  n("Credits, reversal amount", 16),
+		88:  n("Debits, amount", 16),
+		89:  n("Debits, reversal amount", 16),
+		90:  n("Original data elements", 42),
+		91:  an("File update code", 1),
+		92:  an("File security code", 2),
+		93:  an("Response indicator", 5),
+		94:  an("Service indicator", 7),
+		95:  an("Replacement amounts", 42),
+		96:  b("Message security code", 8),
+		97:  an("Amount, net settlement", 17),
+		98:  an("Payee", 25),
+		99:  llvar("Settlement institution identification code", varNumeric),
+		100: llvar("Receiving institution identification code", varNumeric),
+		101: llvar("File name", varText),
+		102: llvar("Account identification 1", varText),
+		103: llvar("Account identification 2", varText),
+		104: lllvar("Transaction description", varText),
+		105: lllvar("Reserved ISO", varText),
+		106: lllvar("Reserved ISO", varText),
+		107: lllvar("Reserved ISO", varText),
+		108: lllvar("Reserved ISO", varText),
+		109: lllvar("Reserved ISO", varText),
+		110: lllvar("Reserved ISO", varText),
+		111: lllvar("Reserved ISO", varText),
+		112: lllvar("Reserved national", varText),
+		113: lllvar("Reserved national", varText),
+		114: lllvar("Reserved national", varText),
+		115: lllvar("Reserved national", varText),
+		116: lllvar("Reserved national", varText),
+		117: lllvar("Reserved national", varText),
+		118: lllvar("Reserved national", varText),
+		119: lllvar("Reserved national", varText),
+		120: lllvar("Reserved private", varText),
+		121: lllvar("Reserved private", varText),
+		122: lllvar("Reserved private", varText),
+		123: lllvar("Reserved private", varText),
+		124: lllvar("Reserved private", varText),
+		125: lllvar("Reserved private", varText),
+		126: lllvar("Reserved private", varText),
+		127: lllvar("Reserved private", varText),
+		128: b("Message authentication code", 8),
+	}
+}
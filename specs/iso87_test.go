@@ -0,0 +1,176 @@
+package specs_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/lazy"
+	"github.com/mercadolibre/go-iso8583/patch"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+	"github.com/mercadolibre/go-iso8583/stream"
+	"github.com/mercadolibre/go-iso8583/trace"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ISO87ASCII_Network_Management(t *testing.T) {
+	spec := specs.ISO87ASCII(specs.HexBitmap)
+
+	// echo test, with the secondary bitmap for the network management information code.
+	data := "0800" + "8220000000000000" + "0400000000000000" + "1019120000" + "000123" + "301"
+	message := serdes.Map{specs.MTIField: "0800", "7": "1019120000", "11": "000123", "70": "301"}
+
+	value, err := spec.Deserialize(bytes.NewBufferString(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := spec.Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.String())
+}
+
+func Test_ISO87ASCII_Published_Bitmap(t *testing.T) {
+	// the bitmap 4210001102C04804 is the example of the ISO 8583 Wikipedia article, it carries the fields 2, 7, 12,
+	// 28, 32, 39, 41, 42, 50, 53 and 62.
+	data := "0210" + "4210001102C04804" +
+		"16" + "4761739001010010" +
+		"1019120000" +
+		"120000" +
+		"D00000050" +
+		"06" + "123456" +
+		"00" +
+		"TERM0001" +
+		"MERCHANT0000001" +
+		"840" +
+		"0000000000000000" +
+		"011" + "PRIVATEDATA"
+	message := serdes.Map{
+		specs.MTIField: "0210",
+		"2":            "4761739001010010",
+		"7":            "1019120000",
+		"12":           "120000",
+		"28":           "D00000050",
+		"32":           "123456",
+		"39":           "00",
+		"41":           "TERM0001",
+		"42":           "MERCHANT0000001",
+		"50":           "840",
+		"53":           "0000000000000000",
+		"62":           "PRIVATEDATA",
+	}
+
+	value, err := specs.ISO87ASCII(specs.HexBitmap).Deserialize(bytes.NewBufferString(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.ISO87ASCII(specs.HexBitmap).Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.String())
+}
+
+func Test_ISO87ASCII_Authorization_Request(t *testing.T) {
+	data := "0200" + "7224048028C09000" +
+		"16" + "4761739001010010" +
+		"000000" +
+		"000000001000" +
+		"1019120000" +
+		"000123" +
+		"2512" +
+		"051" +
+		"00" +
+		"34" + "4761739001010010=25122011143857589" +
+		"123456789012" +
+		"TERM0001" +
+		"MERCHANT0000001" +
+		"840" +
+		"\x01\x23\x45\x67\x89\xab\xcd\xef"
+	message := serdes.Map{
+		specs.MTIField: "0200",
+		"2":            "4761739001010010",
+		"3":            "000000",
+		"4":            "000000001000",
+		"7":            "1019120000",
+		"11":           "000123",
+		"14":           "2512",
+		"22":           "051",
+		"25":           "00",
+		"35":           "4761739001010010=25122011143857589",
+		"37":           "123456789012",
+		"41":           "TERM0001",
+		"42":           "MERCHANT0000001",
+		"49":           "840",
+		"52":           "0123456789abcdef",
+	}
+
+	value, err := specs.ISO87ASCII(specs.HexBitmap).Deserialize(bytes.NewBufferString(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.ISO87ASCII(specs.HexBitmap).Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.String())
+
+	bitmap, _ := hex.DecodeString("7224048028C09000")
+	binaryData := "0200" + string(bitmap) + data[20:]
+
+	value, err = specs.ISO87ASCII(specs.BinaryBitmap).Deserialize(bytes.NewBufferString(binaryData))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err = specs.ISO87ASCII(specs.BinaryBitmap).Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, binaryData, buffer.String())
+}
+
+func Test_ISO87ASCII_Invalid_Length(t *testing.T) {
+	spec := specs.ISO87ASCII(specs.HexBitmap)
+	plan, err := types.Compile(spec)
+	assert.NoError(t, err)
+
+	for _, length := range []string{"-1", "AB"} {
+		data := []byte("0200" + "4000000000000000" + length + "4761")
+
+		_, err := spec.Deserialize(bytes.NewBuffer(data))
+		assert.ErrorIs(t, err, types.ErrInvalidCharacter, length)
+
+		_, err = plan.Deserialize(bytes.NewBuffer(data))
+		assert.ErrorIs(t, err, types.ErrInvalidCharacter, length)
+
+		_, err = types.Scan(spec, data)
+		assert.Error(t, err, length)
+
+		_, errs := types.DeserializePartial(spec, bytes.NewBuffer(data))
+		assert.NotEmpty(t, errs, length)
+
+		_, err = lazy.Decode(spec, data)
+		assert.Error(t, err, length)
+
+		_, _, err = trace.Decode(spec, data)
+		assert.Error(t, err, length)
+
+		_, err = stream.NewDecoder(bytes.NewReader(data), spec).Decode()
+		assert.Error(t, err, length)
+
+		_, err = patch.Apply(spec, data, patch.Set("3", "000000"))
+		assert.Error(t, err, length)
+	}
+}
+
+func Test_ISO87ASCII_Fields(t *testing.T) {
+	spec := specs.ISO87ASCII(specs.HexBitmap).(types.List)
+	bitMapped := spec.Items[1].SerDes.(types.BitMapped)
+
+	assert.Equal(t, types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true}, bitMapped.Bitmap)
+	assert.Len(t, bitMapped.Mapping, 127)
+	for bit := 2; bit <= 128; bit++ {
+		assert.NotNil(t, bitMapped.Mapping[bit], bit)
+	}
+
+	// every call returns a new tree.
+	delete(bitMapped.Mapping, 2)
+	other := specs.ISO87ASCII(specs.HexBitmap).(types.List).Items[1].SerDes.(types.BitMapped)
+	assert.Contains(t, other.Mapping, 2)
+}
@@ -0,0 +1,200 @@
+package specs
+
+import (
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// ISO93ASCII returns the ISO 8583:1993 spec with ASCII data elements and a primary and secondary bitmap.
+//
+// The composite data elements 22 (point of service data code), 30 (amounts, original) and 56 (original data elements)
+// are decoded into maps with their subfields.
+func ISO93ASCII(encoding BitmapEncoding) serdes.Serdes {
+	return message(n("Message type indicator", 4), encoding.bitmap(128), iso93Fields())
+}
+
+// ISO2003ASCII returns the ISO 8583:2003 spec with ASCII data elements and up to a tertiary bitmap, bit 65 is the
+// continuation bit of the secondary bitmap and the data elements 129 to 192 are reserved for ISO use.
+func ISO2003ASCII(encoding BitmapEncoding) serdes.Serdes {
+	fields := iso93Fields()
+	delete(fields, 65)
+	for bit := 129; bit <= 192; bit++ {
+		fields[bit] = lllvar("Reserved ISO", varText)
+	}
+	return message(n("Message type indicator", 4), encoding.bitmap(192), fields)
+}
+
+func iso93Fields() map[int]serdes.Serdes {
+	fields := map[int]serdes.Serdes{
+		2:   llvar("Primary account number", masked(varNumeric, types.MaskPAN)),
+		3:   n("Processing code", 6),
+		4:   n("Amount, transaction", 12),
+		5:   n("Amount, reconciliation", 12),
+		6:   n("Amount, cardholder billing", 12),
+		7:   n("Date and time, transmission", 10),
+		8:   n("Amount, cardholder billing fee", 8),
+		9:   n("Conversion rate, reconciliation", 8),
+		10:  n("Conversion rate, cardholder billing", 8),
+		11:  n("System trace audit number", 6),
+		12:  n("Date and time, local transaction", 12),
+		13:  n("Date, effective", 4),
+		14:  n("Date, expiration", 4),
+		15:  n("Date, settlement", 6),
+		16:  n("Date, conversion", 4),
+		17:  n("Date, capture", 4),
+		18:  n("Merchant type", 4),
+		19:  n("Country code, acquiring institution", 3),
+		20:  n("Country code, primary account number", 3),
+		21:  n("Country code, forwarding institution", 3),
+		22:  posDataCode(),
+		23:  n("Card sequence number", 3),
+		24:  n("Function code", 3),
+		25:  n("Message reason code", 4),
+		26:  n("Card acceptor business code", 4),
+		27:  n("Approval code length", 1),
+		28:  n("Date, reconciliation", 6),
+		29:  n("Reconciliation indicator", 3),
+		30:  originalAmounts(),
+		31:  llvar("Acquirer reference data", varText),
+		32:  llvar("Acquiring institution identification code", varNumeric),
+		33:  llvar("Forwarding institution identification code", varNumeric),
+		34:  llvar("Primary account number, extended", masked(varText, types.MaskPAN)),
+		35:  llvar("Track 2 data", masked(varText, types.MaskAll)),
+		36:  lllvar("Track 3 data", masked(varText, types.MaskAll)),
+		37:  an("Retrieval reference number", 12),
+		38:  an("Approval code", 6),
+		39:  n("Action code", 3),
+		40:  n("Service code", 3),
+		41:  an("Card acceptor terminal identification", 8),
+		42:  an("Card acceptor identification code", 15),
+		43:  llvar("Card acceptor name/location", varText),
+		44:  llvar("Additional response data", varText),
+		45:  llvar("Track 1 data", masked(varText, types.MaskAll)),
+		46:  lllvar("Amounts, fees", varText),
+		47:  lllvar("Additional data, national", varText),
+		48:  lllvar("Additional data, private", varText),
+		49:  an("Currency code, transaction", 3),
+		50:  an("Currency code, reconciliation", 3),
+		51:  an("Currency code, cardholder billing", 3),
+		52:  masked(b("Personal identification number data", 8), types.MaskAll),
+		53:  llvar("Security related control information", varBinary),
+		54:  lllvar("Amounts, additional", varText),
+		55:  lllvar("Integrated circuit card system related data", varBinary),
+		56:  originalDataElements(),
+		57:  n("Authorization life cycle code", 3),
+		58:  llvar("Authorizing agent institution identification code", varNumeric),
+		59:  lllvar("Transport data", varText),
+		60:  lllvar("Reserved national", varText),
+		61:  lllvar("Reserved national", varText),
+		62:  lllvar("Reserved private", varText),
+		63:  lllvar("Reserved private", varText),
+		64:  b("Message authentication code", 8),
+		65:  b("Reserved ISO", 8),
+		66:  lllvar("Amounts, original fees", varText),
+		67:  n("Extended payment data", 2),
+		68:  n("Country code, receiving institution", 3),
+		69:  n("Country code, settlement institution", 3),
+		70:  n("Country code, authorizing agent institution", 3),
+		71:  n("Message number", 8),
+		72:  lllvar("Data record", varText),
+		73:  n("Date, action", 6),
+		74:  n("Credits, number", 10),
+		75:  n("Credits, reversal number", 10),
+		76:  n("Debits, number", 10),
+		77:  n("Debits, reversal number", 10),
+		78:  n("Transfer, number", 10),
+		79:  n("Transfer, reversal number", 10),
+		80:  n("Inquiries, number", 10),
+		81:  n("Authorizations, number", 10),
+		82:  n("Inquiries, reversal number", 10),
+		83:  n("Payments, number", 10),
+		84:  n("Payments, reversal number", 10),
+		85:  n("Fee collections, number", 10),
+		86:  n("Credits, amount", 16),
+		87:  n("Credits, reversal amount", 16),
+		88:  n("Debits, amount", 16),
+		89:  n("Debits, reversal amount", 16),
+		90:  n("Authorizations, reversal number", 10),
+		91:  n("Country code, transaction destination institution", 3),
+		92:  n("Country code, transaction originator institution", 3),
+		93:  llvar("Transaction destination institution identification code", varNumeric),
+		94:  llvar("Transaction originator institution identification code", varNumeric),
+		95:  llvar("Card issuer reference data", varText),
+		96:  lllvar("Key management data", varBinary),
+		97:  an("Amount, net reconciliation", 17),
+		98:  an("Payee", 25),
+		99:  llvar("Settlement institution identification code", varText),
+		100: llvar("Receiving institution identification code", varNumeric),
+		101: llvar("File name", varText),
+		102: llvar("Account identification 1", varText),
+		103: llvar("Account identification 2", varText),
+		104: lllvar("Transaction description", varText),
+		105: n("Credits, chargeback amount", 16),
+		106: n("Debits, chargeback amount", 16),
+		107: n("Credits, chargeback number", 10),
+		108: n("Debits, chargeback number", 10),
+		109: llvar("Credits, fee amounts", varText),
+		110: llvar("Debits, fee amounts", varText),
+		128: b("Message authentication code", 8),
+	}
+
+	for bit := 111; bit <= 127; bit++ {
+		switch {
+		case bit <= 115:
+			fields[bit] = lllvar("Reserved ISO", varText)
+		case bit <= 122:
+			fields[bit] = lllvar("Reserved national", varText)
+		default:
+			fields[bit] = lllvar("Reserved private", varText)
+		}
+	}
+
+	return fields
+}
+
+// posDataCodes are the positions of the point of service data code, data element 22 of ISO 8583:1993.
+var posDataCodes = []struct{ name, desc string }{
+	{"card_data_input_capability", "Card data input capability"},
+	{"cardholder_authentication_capability", "Cardholder authentication capability"},
+	{"card_capture_capability", "Card capture capability"},
+	{"operating_environment", "Operating environment"},
+	{"cardholder_present", "Cardholder present"},
+	{"card_present", "Card present"},
+	{"card_data_input_mode", "Card data input mode"},
+	{"cardholder_authentication_method", "Cardholder authentication method"},
+	{"cardholder_authentication_entity", "Cardholder authentication entity"},
+	{"card_data_output_capability", "Card data output capability"},
+	{"terminal_output_capability", "Terminal output capability"},
+	{"pin_capture_capability", "PIN capture capability"},
+}
+
+func posDataCode() types.List {
+	list := types.List{Desc: "Point of service data code"}
+	for _, code := range posDataCodes {
+		list.Items = append(list.Items, types.Field{Name: code.name, SerDes: an(code.desc, 1)})
+	}
+	return list
+}
+
+// originalAmounts is the data element 30 of ISO 8583:1993.
+func originalAmounts() types.List {
+	return types.List{
+		Desc: "Amounts, original",
+		Items: []types.Field{
+			{Name: "original_amount_transaction", SerDes: n("Original amount, transaction", 12)},
+			{Name: "original_amount_reconciliation", SerDes: n("Original amount, reconciliation", 12)},
+		},
+	}
+}
+
+// originalDataElements is the data element 56 of ISO 8583:1993.
+func originalDataElements() types.VarLength {
+	return llvar("Original data elements", types.List{
+		Items: []types.Field{
+			{Name: "original_mti", SerDes: n("Original message type indicator", 4)},
+			{Name: "original_stan", SerDes: n("Original system trace audit number", 6)},
+			{Name: "original_local_date_time", SerDes: n("Original date and time, local transaction", 12)},
+			{Name: "original_acquiring_institution", SerDes: llvar("Original acquiring institution identification code", varNumeric)},
+		},
+	})
+}
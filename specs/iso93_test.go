@@ -0,0 +1,110 @@
+package specs_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ISO93ASCII_Authorization_Request(t *testing.T) {
+	data := "1100" + "7030050000000000" +
+		"16" + "4761739001010010" +
+		"000000" +
+		"000000001000" +
+		"000123" +
+		"261019120000" +
+		"510101511001" +
+		"100"
+	message := serdes.Map{
+		specs.MTIField: "1100",
+		"2":            "4761739001010010",
+		"3":            "000000",
+		"4":            "000000001000",
+		"11":           "000123",
+		"12":           "261019120000",
+		"22": serdes.Map{
+			"card_data_input_capability":           "5",
+			"cardholder_authentication_capability": "1",
+			"card_capture_capability":              "0",
+			"operating_environment":                "1",
+			"cardholder_present":                   "0",
+			"card_present":                         "1",
+			"card_data_input_mode":                 "5",
+			"cardholder_authentication_method":     "1",
+			"cardholder_authentication_entity":     "1",
+			"card_data_output_capability":          "0",
+			"terminal_output_capability":           "0",
+			"pin_capture_capability":               "1",
+		},
+		"24": "100",
+	}
+
+	value, err := specs.ISO93ASCII(specs.HexBitmap).Deserialize(bytes.NewBufferString(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.ISO93ASCII(specs.HexBitmap).Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.String())
+}
+
+func Test_ISO93ASCII_Reversal_Advice(t *testing.T) {
+	data := "1420" + "3020010400000100" +
+		"000000" +
+		"000000001000" +
+		"000124" +
+		"400" +
+		"000000001000" + "000000000950" +
+		"29" + "1100" + "000123" + "261019120000" + "05" + "12345"
+	message := serdes.Map{
+		specs.MTIField: "1420",
+		"3":            "000000",
+		"4":            "000000001000",
+		"11":           "000124",
+		"24":           "400",
+		"30": serdes.Map{
+			"original_amount_transaction":    "000000001000",
+			"original_amount_reconciliation": "000000000950",
+		},
+		"56": serdes.Map{
+			"original_mti":                   "1100",
+			"original_stan":                  "000123",
+			"original_local_date_time":       "261019120000",
+			"original_acquiring_institution": "12345",
+		},
+	}
+
+	value, err := specs.ISO93ASCII(specs.HexBitmap).Deserialize(bytes.NewBufferString(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.ISO93ASCII(specs.HexBitmap).Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.String())
+}
+
+func Test_ISO2003ASCII_Tertiary_Bitmap(t *testing.T) {
+	data := "1804" + "A000000000000000" + "8400000000000000" + "4000000000000000" +
+		"000000" +
+		"840" +
+		"005" + "HELLO"
+	message := serdes.Map{specs.MTIField: "1804", "3": "000000", "70": "840", "130": "HELLO"}
+
+	value, err := specs.ISO2003ASCII(specs.HexBitmap).Deserialize(bytes.NewBufferString(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.ISO2003ASCII(specs.HexBitmap).Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.String())
+
+	bitMapped := specs.ISO2003ASCII(specs.BinaryBitmap).(types.List).Items[1].SerDes.(types.BitMapped)
+	assert.Equal(t, types.Bitmap{BlockSize: 64, NumBits: 192}, bitMapped.Bitmap)
+	assert.NotContains(t, bitMapped.Mapping, 65)
+	assert.Len(t, bitMapped.Mapping, 190)
+}
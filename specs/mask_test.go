@@ -0,0 +1,45 @@
+package specs_test
+
+import (
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Sensitive_Fields_Masked(t *testing.T) {
+	iso := []int{2, 34, 35, 36, 45, 52}
+	networks := []int{2, 35, 45, 52}
+	tests := []struct {
+		name string
+		spec serdes.Serdes
+		bits []int
+	}{
+		{name: "iso87", spec: specs.ISO87ASCII(specs.HexBitmap), bits: iso},
+		{name: "iso93", spec: specs.ISO93ASCII(specs.HexBitmap), bits: iso},
+		{name: "iso2003", spec: specs.ISO2003ASCII(specs.HexBitmap), bits: iso},
+		{name: "visa", spec: specs.VisaBaseI(), bits: networks},
+		{name: "mastercard", spec: specs.Mastercard(), bits: networks},
+		{name: "amex", spec: specs.AmexGCAG(), bits: networks},
+		{name: "discover", spec: specs.Discover(specs.HexBitmap), bits: iso},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := tt.spec.(types.List).Items
+			mapping := items[len(items)-1].SerDes.(types.BitMapped).Mapping
+			for _, bit := range tt.bits {
+				field := mapping[bit]
+				if varLen, ok := field.(types.VarLength); ok {
+					field = varLen.Data
+				}
+
+				masker, ok := field.(types.Masker)
+				assert.True(t, ok && masker.Sensitive(), "field %d", bit)
+			}
+		})
+	}
+}
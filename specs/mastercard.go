@@ -0,0 +1,176 @@
+package specs
+
+import (
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// Mastercard returns the Mastercard Banknet/CIS authorization spec: an EBCDIC MTI, a binary primary and secondary
+// bitmap and EBCDIC fields with EBCDIC length prefixes.
+//
+// The DE 48 is decoded into its transaction category code and a map of subelements, the DE 112 into a map of
+// subelements with 3 digits tags and lengths, and the DE 60, 61 and 63 into maps with their positional subfields.
+// The DE 124 (member-defined data) has no standard layout, so it is kept as text.
+func Mastercard() serdes.Serdes {
+	return message(en("Message type identifier", 4), types.Bitmap{BlockSize: 64, NumBits: 128}, mastercardFields())
+}
+
+func mastercardFields() map[int]serdes.Serdes {
+	return map[int]serdes.Serdes{
+		2:   ellvar("Primary account number", masked(varEbcdicNumeric, types.MaskPAN)),
+		3:   en("Processing code", 6),
+		4:   en("Amount, transaction", 12),
+		5:   en("Amount, settlement", 12),
+		6:   en("Amount, cardholder billing", 12),
+		7:   en("Transmission date and time", 10),
+		9:   en("Conversion rate, settlement", 8),
+		10:  en("Conversion rate, cardholder billing", 8),
+		11:  en("System trace audit number", 6),
+		12:  en("Time, local transaction", 6),
+		13:  en("Date, local transaction", 4),
+		14:  en("Date, expiration", 4),
+		15:  en("Date, settlement", 4),
+		16:  en("Date, conversion", 4),
+		18:  en("Merchant type", 4),
+		20:  en("Primary account number country code", 3),
+		22:  en("Point of service entry mode", 3),
+		23:  en("Card sequence number", 3),
+		26:  en("Point of service personal ID number capture code", 2),
+		28:  ebcdic("Amount, transaction fee", 9),
+		32:  ellvar("Acquiring institution ID code", varEbcdicNumeric),
+		33:  ellvar("Forwarding institution ID code", varEbcdicNumeric),
+		35:  ellvar("Track 2 data", masked(varEbcdic, types.MaskAll)),
+		37:  ebcdic("Retrieval reference number", 12),
+		38:  ebcdic("Authorization ID response", 6),
+		39:  ebcdic("Response code", 2),
+		41:  ebcdic("Card acceptor terminal ID", 8),
+		42:  ebcdic("Card acceptor ID code", 15),
+		43:  ebcdic("Card acceptor name/location", 40),
+		45:  ellvar("Track 1 data", masked(varEbcdic, types.MaskAll)),
+		48:  mastercardAdditionalData(),
+		49:  en("Currency code, transaction", 3),
+		50:  en("Currency code, settlement", 3),
+		51:  en("Currency code, cardholder billing", 3),
+		52:  masked(b("Personal ID number data", 8), types.MaskAll),
+		53:  en("Security-related control information", 16),
+		54:  elllvar("Additional amounts", varEbcdic),
+		55:  elllvar("Integrated circuit card system-related data", types.BerTLV{Items: emvTags()}),
+		56:  elllvar("Payment account data", varEbcdic),
+		60:  mastercardAdviceReasonCode(),
+		61:  mastercardPOSData(),
+		62:  elllvar("Intermediate network facility data", varEbcdic),
+		63:  mastercardNetworkData(),
+		70:  en("Network management information code", 3),
+		90:  en("Original data elements", 42),
+		94:  ebcdic("Service indicator", 7),
+		95:  ebcdic("Replacement amounts", 42),
+		100: ellvar("Receiving institution ID code", varEbcdicNumeric),
+		102: ellvar("Account ID 1", varEbcdic),
+		103: ellvar("Account ID 2", varEbcdic),
+		108: elllvar("Additional transaction reference data", varEbcdic),
+		112: mastercardNationalData(),
+		120: elllvar("Record data", varEbcdic),
+		121: elllvar("Authorizing agent ID code", varEbcdic),
+		124: elllvar("Member-defined data", varEbcdic),
+		127: elllvar("Private data", varEbcdic),
+	}
+}
+
+// mastercardAdditionalData is the DE 48, the transaction category code followed by the subelements.
+func mastercardAdditionalData() types.VarLength {
+	return elllvar("Additional data, private use", types.List{
+		Items: []types.Field{
+			{Name: "tcc", SerDes: ebcdic("Transaction category code", 1)},
+			{Name: "se", SerDes: types.TLV{
+				Desc:    "Subelements",
+				SizeLen: 2,
+				SizeTag: 2,
+				Items: []types.Field{
+					{Name: "10", SerDes: types.Ebcdic{Desc: "Encrypted PIN block key"}},
+					{Name: "20", SerDes: types.Ebcdic{Desc: "Cardholder verification method"}},
+					{Name: "21", SerDes: types.Ebcdic{Desc: "Acceptance data"}},
+					{Name: "22", SerDes: types.Ebcdic{Desc: "Multi-purpose merchant indicator"}},
+					{Name: "23", SerDes: types.Ebcdic{Desc: "Payment initiation channel"}},
+					{Name: "26", SerDes: types.Ebcdic{Desc: "Wallet program data"}},
+					{Name: "32", SerDes: types.Ebcdic{Desc: "Mastercard assigned ID"}},
+					{Name: "33", SerDes: types.Ebcdic{Desc: "PAN mapping file information"}},
+					{Name: "37", SerDes: types.Ebcdic{Desc: "Additional merchant data"}},
+					{Name: "42", SerDes: types.Ebcdic{Desc: "Electronic commerce indicators"}},
+					{Name: "43", SerDes: types.Ebcdic{Desc: "Universal cardholder authentication field"}},
+					{Name: "61", SerDes: types.Ebcdic{Desc: "POS data, extended condition codes"}},
+					{Name: "63", SerDes: types.Ebcdic{Desc: "Trace ID"}},
+					{Name: "66", SerDes: types.Ebcdic{Desc: "Authentication data"}},
+					{Name: "71", SerDes: types.Ebcdic{Desc: "On-behalf services"}},
+					{Name: "74", SerDes: types.Ebcdic{Desc: "Additional processing information"}},
+					{Name: "77", SerDes: types.Ebcdic{Desc: "Transaction type identifier"}},
+					{Name: "80", SerDes: types.Ebcdic{Desc: "PIN service code"}},
+					{Name: "82", SerDes: types.Ebcdic{Desc: "Address verification service request"}},
+					{Name: "83", SerDes: types.Ebcdic{Desc: "Address verification service response"}},
+					{Name: "84", SerDes: types.Ebcdic{Desc: "Merchant advice code"}},
+					{Name: "87", SerDes: types.Ebcdic{Desc: "Card validation code result"}},
+					{Name: "88", SerDes: types.Ebcdic{Desc: "Magnetic stripe compliance status indicator"}},
+					{Name: "89", SerDes: types.Ebcdic{Desc: "Magnetic stripe compliance error indicator"}},
+					{Name: "92", SerDes: types.Ebcdic{Desc: "CVC 2"}},
+					{Name: "95", SerDes: types.Ebcdic{Desc: "Promotion code"}},
+				},
+			}},
+		},
+	})
+}
+
+// mastercardAdviceReasonCode is the DE 60.
+func mastercardAdviceReasonCode() types.VarLength {
+	return elllvar("Advice reason code", types.List{
+		Items: []types.Field{
+			{Name: "code", SerDes: en("Advice reason code", 3)},
+			{Name: "detail_code", SerDes: en("Advice detail code", 4)},
+			{Name: "detail_text", SerDes: types.Ebcdic{Desc: "Advice detail text"}},
+		},
+	})
+}
+
+// mastercardPOSData is the DE 61, the postal code is optional.
+func mastercardPOSData() types.VarLength {
+	return elllvar("Point of service data", types.List{
+		Items: []types.Field{
+			{Name: "terminal_attendance", SerDes: en("POS terminal attendance", 1)},
+			{Name: "reserved_2", SerDes: en("Reserved", 1)},
+			{Name: "terminal_location", SerDes: en("POS terminal location", 1)},
+			{Name: "cardholder_presence", SerDes: en("POS cardholder presence", 1)},
+			{Name: "card_presence", SerDes: en("POS card presence", 1)},
+			{Name: "card_capture_capabilities", SerDes: en("POS card capture capabilities", 1)},
+			{Name: "transaction_status", SerDes: en("POS transaction status", 1)},
+			{Name: "transaction_security", SerDes: en("POS transaction security", 1)},
+			{Name: "reserved_9", SerDes: en("Reserved", 1)},
+			{Name: "cardholder_activated_terminal_level", SerDes: en("Cardholder-activated terminal level", 1)},
+			{Name: "card_data_terminal_input_capability", SerDes: en("POS card data terminal input capability", 1)},
+			{Name: "authorization_life_cycle", SerDes: en("POS authorization life cycle", 2)},
+			{Name: "country_code", SerDes: en("POS country code", 3)},
+			{Name: "postal_code", SerDes: types.Ebcdic{Desc: "POS postal code"}},
+		},
+	})
+}
+
+// mastercardNetworkData is the DE 63.
+func mastercardNetworkData() types.VarLength {
+	return elllvar("Network data", types.List{
+		Items: []types.Field{
+			{Name: "financial_network_code", SerDes: ebcdic("Financial network code", 3)},
+			{Name: "banknet_reference_number", SerDes: types.Ebcdic{Desc: "Banknet reference number"}},
+		},
+	})
+}
+
+// mastercardNationalData is the DE 112, its subelements have 3 digits tags and lengths.
+func mastercardNationalData() types.VarLength {
+	return elllvar("Additional data, national use", types.TLV{
+		SizeLen: 3,
+		SizeTag: 3,
+		Items: []types.Field{
+			{Name: "001", SerDes: types.Ebcdic{Desc: "Installment payment data"}},
+			{Name: "002", SerDes: types.Ebcdic{Desc: "Installment payment response data"}},
+			{Name: "010", SerDes: types.Ebcdic{Desc: "Installment payment data, Brazil"}},
+			{Name: "012", SerDes: types.Ebcdic{Desc: "Installment payment response data, Brazil"}},
+		},
+	})
+}
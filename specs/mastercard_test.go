@@ -0,0 +1,84 @@
+package specs_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Mastercard_Authorization_Request(t *testing.T) {
+	data, _ := hex.DecodeString(strings.Join([]string{
+		"f0f1f0f0",
+		"7220040000010008",
+		"f1f6" + "f5f4f1f2f3f4f5f6f7f8f9f0f1f2f3f4",
+		"f0f0f0f0f0f0",
+		"f0f0f0f0f0f0f0f1f0f0f0f0",
+		"f1f0f1f9f1f2f0f0f0f0",
+		"f0f0f0f4f5f6",
+		"f0f5f1",
+		"f0f0f8" + "e3" + "f9f2f0f3f1f2f3",
+		"f0f2f1" + "f0f0f1f0f0f0f0f0f0f0f3f0f0f0f7f6" + "f1f2f3f4f5",
+	}, ""))
+	message := serdes.Map{
+		specs.MTIField: "0100",
+		"2":            "5412345678901234",
+		"3":            "000000",
+		"4":            "000000010000",
+		"7":            "1019120000",
+		"11":           "000456",
+		"22":           "051",
+		"48":           serdes.Map{"tcc": "T", "se": serdes.Map{"92": "123"}},
+		"61": serdes.Map{
+			"terminal_attendance":                 "0",
+			"reserved_2":                          "0",
+			"terminal_location":                   "1",
+			"cardholder_presence":                 "0",
+			"card_presence":                       "0",
+			"card_capture_capabilities":           "0",
+			"transaction_status":                  "0",
+			"transaction_security":                "0",
+			"reserved_9":                          "0",
+			"cardholder_activated_terminal_level": "0",
+			"card_data_terminal_input_capability": "3",
+			"authorization_life_cycle":            "00",
+			"country_code":                        "076",
+			"postal_code":                         "12345",
+		},
+	}
+
+	value, err := specs.Mastercard().Deserialize(bytes.NewBuffer(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.Mastercard().Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.Bytes())
+}
+
+func Test_Mastercard_National_Data(t *testing.T) {
+	data, _ := hex.DecodeString(strings.Join([]string{
+		"f0f1f1f0",
+		"8000000002000000", "0000000000010000",
+		"f0f0",
+		"f0f1f0" + "f0f0f1f0f0f4f2f0f0f1",
+	}, ""))
+	message := serdes.Map{
+		specs.MTIField: "0110",
+		"39":           "00",
+		"112":          serdes.Map{"001": "2001"},
+	}
+
+	value, err := specs.Mastercard().Deserialize(bytes.NewBuffer(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.Mastercard().Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.Bytes())
+}
@@ -0,0 +1,133 @@
+// Package specs provides ready to use serdes trees of the ISO 8583 versions and of the card networks messages.
+//
+// The messages are a List with the MTI, named "mti", followed by an anonymous BitMapped with the data elements, so the
+// deserialized values are a flat map keyed by the MTI field name and the bit numbers. The network specs with a header
+// have it as the first item of the List, named "header". Every function returns a new
+// tree, so callers can modify it without affecting other users of the spec.
+package specs
+
+import (
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// MTIField is the name of the message type indicator field.
+const MTIField = "mti"
+
+// BitmapEncoding is the encoding of the bitmaps of the ASCII specs.
+type BitmapEncoding int
+
+const (
+	// HexBitmap encodes every bitmap block as 16 hexadecimal ASCII characters.
+	HexBitmap BitmapEncoding = iota
+	// BinaryBitmap encodes every bitmap block as 8 bytes.
+	BinaryBitmap
+)
+
+func (encoding BitmapEncoding) bitmap(numBits int) types.Bitmap {
+	return types.Bitmap{BlockSize: 64, NumBits: numBits, Hex: encoding == HexBitmap}
+}
+
+func message(mti serdes.Serdes, bitmap types.Bitmap, mapping map[int]serdes.Serdes) types.List {
+	return types.List{
+		Items: []types.Field{
+			{Name: MTIField, SerDes: mti},
+			{SerDes: types.BitMapped{Bitmap: bitmap, Mapping: mapping}},
+		},
+	}
+}
+
+// n is a fixed size ASCII numeric field.
+func n(desc string, digits int) types.AsciiNumeric {
+	return types.AsciiNumeric{Desc: types.Desc(desc), NumDigits: digits}
+}
+
+// an is a fixed size ASCII field, left justified and padded with spaces.
+func an(desc string, size int) types.Ascii {
+	return types.Ascii{Desc: types.Desc(desc), NumDigits: size}
+}
+
+// b is a fixed size binary field.
+func b(desc string, size int) types.Raw {
+	return types.Raw{Desc: types.Desc(desc), NumBytes: size}
+}
+
+// llvar is a variable length field with a 2 digits ASCII length prefix.
+func llvar(desc string, data serdes.Serdes) types.VarLength {
+	return types.VarLength{Desc: types.Desc(desc), Length: types.AsciiNumeric{NumDigits: 2}, Data: data}
+}
+
+// lllvar is a variable length field with a 3 digits ASCII length prefix.
+func lllvar(desc string, data serdes.Serdes) types.VarLength {
+	return types.VarLength{Desc: types.Desc(desc), Length: types.AsciiNumeric{NumDigits: 3}, Data: data}
+}
+
+// Variable length data, the description is set in the VarLength.
+var (
+	varNumeric = types.AsciiNumeric{}
+	varText    = types.Ascii{}
+	varBinary  = types.Raw{}
+)
+
+// bcd is a fixed size packed BCD numeric field, the leading zeros are kept in the decoded value.
+func bcd(desc string, digits int) types.Bcd {
+	return types.Bcd{Desc: types.Desc(desc), NumDigits: digits, NotPadded: true}
+}
+
+// ebcdic is a fixed size EBCDIC field, left justified and padded with spaces.
+func ebcdic(desc string, size int) types.Ebcdic {
+	return types.Ebcdic{Desc: types.Desc(desc), NumDigits: size}
+}
+
+// en is a fixed size EBCDIC numeric field.
+func en(desc string, digits int) types.EbcdicNumeric {
+	return types.EbcdicNumeric{Desc: types.Desc(desc), NumDigits: digits}
+}
+
+// Variable length EBCDIC and BCD data, the description is set in the VarLength.
+var (
+	varBcd           = types.Bcd{}
+	varEbcdic        = types.Ebcdic{}
+	varEbcdicNumeric = types.EbcdicNumeric{}
+)
+
+// ellvar is a variable length field with a 2 digits EBCDIC length prefix.
+func ellvar(desc string, data serdes.Serdes) types.VarLength {
+	return types.VarLength{Desc: types.Desc(desc), Length: types.EbcdicNumeric{NumDigits: 2}, Data: data}
+}
+
+// elllvar is a variable length field with a 3 digits EBCDIC length prefix.
+func elllvar(desc string, data serdes.Serdes) types.VarLength {
+	return types.VarLength{Desc: types.Desc(desc), Length: types.EbcdicNumeric{NumDigits: 3}, Data: data}
+}
+
+// masked returns the leaf with the mask, the values of the sensitive fields are masked in the errors.
+func masked(s serdes.Serdes, mask types.Mask) serdes.Serdes {
+	switch leaf := s.(type) {
+	case types.Bcd:
+		leaf.Mask = mask
+		return leaf
+	case types.Ebcdic:
+		leaf.Mask = mask
+		return leaf
+	case types.EbcdicNumeric:
+		leaf.Mask = mask
+		return leaf
+	case types.Ascii:
+		leaf.Mask = mask
+		return leaf
+	case types.AsciiNumeric:
+		leaf.Mask = mask
+		return leaf
+	case types.Raw:
+		leaf.Mask = mask
+		return leaf
+	case types.Byte:
+		leaf.Mask = mask
+		return leaf
+	case types.Word:
+		leaf.Mask = mask
+		return leaf
+	}
+	return s
+}
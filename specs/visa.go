@@ -0,0 +1,217 @@
+package specs
+
+import (
+	"encoding/binary"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// HeaderField is the name of the header of the network specs that have one.
+const HeaderField = "header"
+
+// VisaBaseI returns the Visa V.I.P. BASE I authorization spec: the 22 bytes header, a BCD MTI, a binary primary and
+// secondary bitmap, BCD numeric and EBCDIC alphanumeric fields with a binary length prefix.
+//
+// The fields 62 (custom payment service), 63 (V.I.P. private use) and 126 (Visa private use) are decoded into maps
+// keyed by their bit numbers, the field 55 into the ICC dataset with its EMV tags. The positional subfields of the
+// field 60 are packed in nibbles, so it is kept as a single BCD value. The total message length of the header must
+// be set by the caller.
+func VisaBaseI() serdes.Serdes {
+	spec := message(bcd("Message type identifier", 4), types.Bitmap{BlockSize: 64, NumBits: 128}, visaFields())
+	spec.Items = append([]types.Field{{Name: HeaderField, SerDes: visaHeader()}}, spec.Items...)
+	return spec
+}
+
+// visaHeader is the V.I.P. header format 1.
+func visaHeader() types.List {
+	return types.List{
+		Desc: "Message header",
+		Items: []types.Field{
+			{Name: "header_length", SerDes: types.Byte{Desc: "Header length"}},
+			{Name: "header_format", SerDes: types.Byte{Desc: "Header flag and format"}},
+			{Name: "text_format", SerDes: types.Byte{Desc: "Text format"}},
+			{Name: "message_length", SerDes: types.Word{Desc: "Total message length", Order: binary.BigEndian}},
+			{Name: "destination_id", SerDes: bcd("Destination station ID", 6)},
+			{Name: "source_id", SerDes: bcd("Source station ID", 6)},
+			{Name: "round_trip_info", SerDes: types.Byte{Desc: "Round-trip control information"}},
+			{Name: "base_i_flags", SerDes: b("BASE I flags", 2)},
+			{Name: "message_status_flags", SerDes: b("Message status flags", 3)},
+			{Name: "batch_number", SerDes: types.Byte{Desc: "Batch number"}},
+			{Name: "reserved", SerDes: b("Reserved", 3)},
+			{Name: "user_info", SerDes: types.Byte{Desc: "User information"}},
+		},
+	}
+}
+
+// bvar is a variable length field with a 1 byte binary length prefix.
+func bvar(desc string, data serdes.Serdes) types.VarLength {
+	return types.VarLength{Desc: types.Desc(desc), Length: types.Byte{}, Data: data}
+}
+
+func visaFields() map[int]serdes.Serdes {
+	return map[int]serdes.Serdes{
+		2:  bvar("Primary account number", masked(varBcd, types.MaskPAN)),
+		3:  bcd("Processing code", 6),
+		4:  bcd("Amount, transaction", 12),
+		5:  bcd("Amount, settlement", 12),
+		6:  bcd("Amount, cardholder billing", 12),
+		7:  bcd("Transmission date and time", 10),
+		9:  bcd("Conversion rate, settlement", 8),
+		10: bcd("Conversion rate, cardholder billing", 8),
+		11: bcd("System trace audit number", 6),
+		12: bcd("Time, local transaction", 6),
+		13: bcd("Date, local transaction", 4),
+		14: bcd("Date, expiration", 4),
+		15: bcd("Date, settlement", 4),
+		16: bcd("Date, conversion", 4),
+		18: bcd("Merchant type", 4),
+		19: bcd("Acquiring institution country code", 3),
+		20: bcd("PAN extended, country code", 3),
+		22: bcd("Point of service entry mode code", 4),
+		23: bcd("Card sequence number", 3),
+		25: bcd("Point of service condition code", 2),
+		26: bcd("Point of service PIN capture code", 2),
+		28: types.List{
+			Desc: "Amount, transaction fee",
+			Items: []types.Field{
+				{Name: "credit_debit_indicator", SerDes: ebcdic("Credit or debit indicator", 1)},
+				{Name: "amount", SerDes: bcd("Amount", 8)},
+			},
+		},
+		32:  bvar("Acquiring institution identification code", varBcd),
+		33:  bvar("Forwarding institution identification code", varBcd),
+		35:  bvar("Track 2 data", masked(varBcd, types.MaskAll)),
+		37:  ebcdic("Retrieval reference number", 12),
+		38:  ebcdic("Authorization identification response", 6),
+		39:  ebcdic("Response code", 2),
+		41:  ebcdic("Card acceptor terminal identification", 8),
+		42:  ebcdic("Card acceptor identification code", 15),
+		43:  ebcdic("Card acceptor name/location", 40),
+		44:  bvar("Additional response data", varEbcdic),
+		45:  bvar("Track 1 data", masked(varEbcdic, types.MaskAll)),
+		48:  bvar("Additional data, private", varEbcdic),
+		49:  bcd("Currency code, transaction", 3),
+		50:  bcd("Currency code, settlement", 3),
+		51:  bcd("Currency code, cardholder billing", 3),
+		52:  masked(b("Personal identification number data", 8), types.MaskAll),
+		53:  bcd("Security related control information", 16),
+		54:  bvar("Additional amounts", varEbcdic),
+		55:  visaICCData(),
+		59:  bvar("National point of service geographic data", varEbcdic),
+		60:  bvar("Additional point of service information", varBcd),
+		61:  bvar("Other amounts", varBcd),
+		62:  visaCustomPaymentService(),
+		63:  visaPrivateUse(),
+		70:  bcd("Network management information code", 3),
+		73:  bcd("Date, action", 6),
+		90:  bcd("Original data elements", 42),
+		91:  ebcdic("File update code", 1),
+		95:  ebcdic("Replacement amounts", 42),
+		100: bvar("Receiving institution identification code", varBcd),
+		101: bvar("File name", varEbcdic),
+		102: bvar("Account identification 1", varEbcdic),
+		103: bvar("Account identification 2", varEbcdic),
+		104: bvar("Transaction description", varBinary),
+		126: visaPrivateUseFields(),
+		127: bvar("File maintenance", varBinary),
+	}
+}
+
+// visaICCData is the field 55, a dataset with the EMV tags.
+func visaICCData() types.VarLength {
+	return bvar("Integrated circuit card related data", types.List{
+		Items: []types.Field{
+			{Name: "dataset_id", SerDes: types.Byte{Desc: "Dataset ID"}},
+			{Name: "tags", SerDes: types.VarLength{
+				Desc:   "Dataset",
+				Length: types.Word{Order: binary.BigEndian},
+				Data:   types.BerTLV{Items: emvTags()},
+			}},
+		},
+	})
+}
+
+// emvTags are the EMV tags commonly sent in authorization requests and responses.
+func emvTags() []types.Field {
+	return []types.Field{
+		{Name: "71", SerDes: types.Raw{Desc: "Issuer script template 1"}},
+		{Name: "72", SerDes: types.Raw{Desc: "Issuer script template 2"}},
+		{Name: "82", SerDes: types.Raw{Desc: "Application interchange profile"}},
+		{Name: "84", SerDes: types.Raw{Desc: "Dedicated file name"}},
+		{Name: "91", SerDes: types.Raw{Desc: "Issuer authentication data"}},
+		{Name: "95", SerDes: types.Raw{Desc: "Terminal verification results"}},
+		{Name: "9a", SerDes: types.Raw{Desc: "Transaction date"}},
+		{Name: "9c", SerDes: types.Raw{Desc: "Transaction type"}},
+		{Name: "5f2a", SerDes: types.Raw{Desc: "Transaction currency code"}},
+		{Name: "5f34", SerDes: types.Raw{Desc: "Application PAN sequence number"}},
+		{Name: "9f02", SerDes: types.Raw{Desc: "Amount, authorized"}},
+		{Name: "9f03", SerDes: types.Raw{Desc: "Amount, other"}},
+		{Name: "9f09", SerDes: types.Raw{Desc: "Application version number"}},
+		{Name: "9f10", SerDes: types.Raw{Desc: "Issuer application data"}},
+		{Name: "9f1a", SerDes: types.Raw{Desc: "Terminal country code"}},
+		{Name: "9f1e", SerDes: types.Raw{Desc: "Interface device serial number"}},
+		{Name: "9f26", SerDes: types.Raw{Desc: "Application cryptogram"}},
+		{Name: "9f27", SerDes: types.Raw{Desc: "Cryptogram information data"}},
+		{Name: "9f33", SerDes: types.Raw{Desc: "Terminal capabilities"}},
+		{Name: "9f34", SerDes: types.Raw{Desc: "Cardholder verification method results"}},
+		{Name: "9f35", SerDes: types.Raw{Desc: "Terminal type"}},
+		{Name: "9f36", SerDes: types.Raw{Desc: "Application transaction counter"}},
+		{Name: "9f37", SerDes: types.Raw{Desc: "Unpredictable number"}},
+		{Name: "9f41", SerDes: types.Raw{Desc: "Transaction sequence counter"}},
+		{Name: "9f6e", SerDes: types.Raw{Desc: "Form factor indicator"}},
+	}
+}
+
+// visaCustomPaymentService is the field 62, with an 8 bytes bitmap.
+func visaCustomPaymentService() types.VarLength {
+	return bvar("Custom payment service fields", types.BitMapped{
+		Bitmap: types.Bitmap{BlockSize: 64, NumBits: 64},
+		Mapping: map[int]serdes.Serdes{
+			1:  ebcdic("Authorization characteristics indicator", 1),
+			2:  bcd("Transaction identifier", 15),
+			3:  ebcdic("Validation code", 4),
+			4:  ebcdic("Market-specific data identifier", 1),
+			5:  bcd("Duration", 2),
+			6:  ebcdic("Prestigious property indicator", 1),
+			7:  ebcdic("Purchase identifier", 26),
+			17: ebcdic("Gateway transaction identifier", 15),
+			20: bcd("Merchant verification value", 10),
+			23: ebcdic("Product ID", 2),
+			25: ebcdic("Spend qualified indicator", 1),
+		},
+	})
+}
+
+// visaPrivateUse is the field 63, with a 3 bytes bitmap.
+func visaPrivateUse() types.VarLength {
+	return bvar("V.I.P. private use fields", types.BitMapped{
+		Bitmap: types.Bitmap{BlockSize: 24, NumBits: 24},
+		Mapping: map[int]serdes.Serdes{
+			1:  bcd("Network identification code", 4),
+			2:  bcd("Time (preauthorization time limit)", 4),
+			3:  bcd("Message reason code", 4),
+			4:  bcd("STIP/switch reason code", 4),
+			13: bcd("Decimal positions indicator", 6),
+			19: bcd("Fee program indicator", 3),
+		},
+	})
+}
+
+// visaPrivateUseFields is the field 126, with an 8 bytes bitmap.
+func visaPrivateUseFields() types.VarLength {
+	return bvar("Visa private use fields", types.BitMapped{
+		Bitmap: types.Bitmap{BlockSize: 64, NumBits: 64},
+		Mapping: map[int]serdes.Serdes{
+			5:  ebcdic("Visa merchant identifier", 8),
+			6:  b("Cardholder certificate serial number", 17),
+			7:  b("Merchant certificate serial number", 17),
+			8:  b("Transaction ID", 20),
+			9:  b("CAVV data", 20),
+			10: ebcdic("CVV2 request data", 6),
+			13: ebcdic("POS environment", 1),
+			15: ebcdic("Mastercard UCAF collection indicator", 1),
+			20: ebcdic("3-D Secure indicator", 1),
+		},
+	})
+}
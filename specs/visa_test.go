@@ -0,0 +1,111 @@
+package specs_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VisaBaseI_Authorization_Request(t *testing.T) {
+	data, _ := hex.DecodeString(strings.Join([]string{
+		"160102007c4567891234560000000000000000000000",
+		"0100", "7224048008c08006",
+		"10", "4761739001010010",
+		"000000",
+		"000000001000",
+		"1019120000",
+		"000123",
+		"2512",
+		"0510",
+		"00",
+		"f1f2f3f4f5f6f7f8f9f0f1f2",
+		"e3c5d9d4f0f0f0f1",
+		"d4c5d9c3c8c1d5e3f0f0f0f0f0f0f1",
+		"0840",
+		"11", "c000000000000000", "e8", "0012345678901234",
+		"05", "800000", "0002",
+	}, ""))
+	message := serdes.Map{
+		specs.HeaderField: serdes.Map{
+			"header_length":        "22",
+			"header_format":        "1",
+			"text_format":          "2",
+			"message_length":       "124",
+			"destination_id":       "456789",
+			"source_id":            "123456",
+			"round_trip_info":      "0",
+			"base_i_flags":         "0000",
+			"message_status_flags": "000000",
+			"batch_number":         "0",
+			"reserved":             "000000",
+			"user_info":            "0",
+		},
+		specs.MTIField: "0100",
+		"2":            "4761739001010010",
+		"3":            "000000",
+		"4":            "000000001000",
+		"7":            "1019120000",
+		"11":           "000123",
+		"14":           "2512",
+		"22":           "0510",
+		"25":           "00",
+		"37":           "123456789012",
+		"41":           "TERM0001",
+		"42":           "MERCHANT0000001",
+		"49":           "840",
+		"62":           serdes.Map{"1": "Y", "2": "012345678901234"},
+		"63":           serdes.Map{"1": "0002"},
+	}
+
+	value, err := specs.VisaBaseI().Deserialize(bytes.NewBuffer(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.VisaBaseI().Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.Bytes())
+	assert.Len(t, data, 124)
+}
+
+func Test_VisaBaseI_ICC_Data(t *testing.T) {
+	data, _ := hex.DecodeString(strings.Join([]string{
+		"16010200340000000000000000000000000000000000",
+		"0100", "0000000000000200",
+		"13", "01", "0010", "9f2608a1b2c3d4e5f60718", "9f36020001",
+	}, ""))
+	message := serdes.Map{
+		specs.HeaderField: serdes.Map{
+			"header_length":        "22",
+			"header_format":        "1",
+			"text_format":          "2",
+			"message_length":       "52",
+			"destination_id":       "000000",
+			"source_id":            "000000",
+			"round_trip_info":      "0",
+			"base_i_flags":         "0000",
+			"message_status_flags": "000000",
+			"batch_number":         "0",
+			"reserved":             "000000",
+			"user_info":            "0",
+		},
+		specs.MTIField: "0100",
+		"55": serdes.Map{
+			"dataset_id": "1",
+			"tags":       serdes.Map{"9f26": "a1b2c3d4e5f60718", "9f36": "0001"},
+		},
+	}
+
+	value, err := specs.VisaBaseI().Deserialize(bytes.NewBuffer(data))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	buffer, err := specs.VisaBaseI().Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buffer.Bytes())
+}
@@ -0,0 +1,292 @@
+// Package stream decodes messages from an io.Reader and encodes them to an io.Writer.
+//
+// The Decoder reads only the bytes of each field: the fixed size fields, the length prefix and the data of VarLength
+// fields and the bitmap blocks of BitMapped fields are read as they are needed, and the message is decoded by the
+// serdes once all its bytes were read. The fields whose size is only known from the end of the data, like a TLV
+// that is not wrapped by a VarLength, are read until the end of the stream. The messages are limited to
+// DefaultMaxMessageSize bytes, so a corrupted length doesn't make the Decoder read or allocate more bytes than that.
+//
+// Messages framed by a length header are decoded wrapping the spec with a VarLength, example:
+//
+//	decoder := stream.NewDecoder(conn, types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: spec})
+//	for {
+//		value, err := decoder.Decode()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+package stream
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// DefaultMaxMessageSize is the maximum size of the messages read by a Decoder, the size of the messages with a length
+// header of two bytes.
+const DefaultMaxMessageSize = 64 * 1024
+
+// ErrMessageTooLong is the error of the messages longer than the maximum size of the Decoder.
+var ErrMessageTooLong = errors.New("message too long")
+
+// Error is the error of the package, see serdes.Error.
+type Error = serdes.Error
+
+// Decoder reads and decodes messages from a reader.
+type Decoder struct {
+	r       io.Reader
+	serdes  serdes.Serdes
+	read    int
+	maxSize int
+}
+
+func NewDecoder(r io.Reader, s serdes.Serdes) *Decoder {
+	return &Decoder{r: r, serdes: s, maxSize: DefaultMaxMessageSize}
+}
+
+// SetMaxMessageSize sets the maximum size of the messages, the lengths read from the stream are checked against it
+// before reading the bytes of the field.
+func (d *Decoder) SetMaxMessageSize(size int) {
+	d.maxSize = size
+}
+
+// Decode reads the next message and decodes it. It returns io.EOF when the reader ends before the message starts and
+// an error wrapping io.ErrUnexpectedEOF when it ends in the middle of the message.
+func (d *Decoder) Decode() (serdes.Value, error) {
+	message, err := d.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := d.serdes.Deserialize(bytes.NewBuffer(message))
+	if err != nil {
+		return nil, Error{Message: "error decoding message", Cause: err}
+	}
+	return value, nil
+}
+
+// Next reads the bytes of the next message without decoding it.
+func (d *Decoder) Next() ([]byte, error) {
+	d.read = 0
+	out := new(bytes.Buffer)
+	err := d.frame("", d.serdes, out)
+	if d.read == 0 && (err == nil || errors.Is(err, io.EOF)) {
+		return nil, io.EOF
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		var streamErr Error
+		errors.As(err, &streamErr)
+		return nil, Error{Message: "unexpected end of message", Path: streamErr.Path, Cause: io.ErrUnexpectedEOF}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// frame reads the bytes of the serdes into out.
+func (d *Decoder) frame(path string, s serdes.Serdes, out *bytes.Buffer) error {
+	switch value := s.(type) {
+	case types.VarLength:
+		return d.frameVarLength(path, value, out)
+	case types.List:
+		return d.frameList(path, value, out)
+	case types.BitMapped:
+		return d.frameBitMapped(path, value, out)
+	}
+
+	if introspectable, ok := s.(types.Introspectable); ok {
+		if size, fixed := introspectable.FixedSize(); fixed {
+			return d.readFull(path, size, out)
+		}
+	}
+
+	// one more byte than the remaining size is read to find the messages that are too long.
+	_, err := io.Copy(out, io.LimitReader(d.counter(), int64(d.maxSize-d.read+1)))
+	if err != nil {
+		return Error{Message: "error reading data", Path: path, Cause: err}
+	}
+
+	if d.read > d.maxSize {
+		return d.tooLong(path)
+	}
+	return nil
+}
+
+// frameVarLength reads the length prefix and then the data, the length of Bcd data is counted in digits.
+func (d *Decoder) frameVarLength(path string, varLen types.VarLength, out *bytes.Buffer) error {
+	start := out.Len()
+	if err := d.frame(path, varLen.Length, out); err != nil {
+		return err
+	}
+
+	prefix := append([]byte{}, out.Bytes()[start:]...)
+	lengthValue, err := varLen.Length.Deserialize(bytes.NewBuffer(prefix))
+	if err != nil {
+		return Error{Message: "error decoding length", Path: path, Cause: err}
+	}
+
+	lengthStr, _ := lengthValue.(string)
+	length, err := strconv.Atoi(lengthStr)
+	if err != nil || length < 0 {
+		return Error{Message: fmt.Sprintf("invalid length %v", lengthValue), Path: path, Cause: err}
+	}
+
+	if _, ok := varLen.Data.(types.Bcd); ok {
+		length = (length + 1) / 2
+	}
+	return d.readFull(path, length, out)
+}
+
+// frameList reads the items until the end of the list or of the stream, like List.Deserialize does with the buffer.
+func (d *Decoder) frameList(path string, list types.List, out *bytes.Buffer) error {
+	for _, item := range list.Items {
+		itemPath := path
+		if item.Name != "" {
+			itemPath = serdes.JoinPath(path, item.Name)
+		}
+
+		before := d.read
+		if err := d.frame(itemPath, item.SerDes, out); err != nil {
+			if errors.Is(err, io.EOF) && d.read == before {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// frameBitMapped reads the bitmap block by block and then the fields of the bits that are set.
+func (d *Decoder) frameBitMapped(path string, bitMapped types.BitMapped, out *bytes.Buffer) error {
+	bitmap := bitMapped.Bitmap
+	if bitmap.BlockSize <= 0 {
+		return Error{Message: "invalid bitmap block size", Path: path}
+	}
+
+	blockSize := bitmap.BlockSize / 8
+	if bitmap.Hex {
+		blockSize *= 2
+	}
+
+	start := out.Len()
+	maxNumBlocks := bitmap.NumBits / bitmap.BlockSize
+	for blockIndex := 0; blockIndex < maxNumBlocks; blockIndex++ {
+		blockStart := out.Len()
+		if err := d.readFull(path, blockSize, out); err != nil {
+			return err
+		}
+
+		if !moreBlocks(out.Bytes()[blockStart:], bitmap.Hex) {
+			break
+		}
+	}
+
+	encoded := append([]byte{}, out.Bytes()[start:]...)
+	bitmapValue, err := bitmap.Deserialize(bytes.NewBuffer(encoded))
+	if err != nil {
+		return Error{Message: "error decoding bitmap", Path: path, Cause: err}
+	}
+
+	bits, _ := bitmapValue.([]byte)
+	for bitNumber := 1; bitNumber <= len(bits)*8; bitNumber++ {
+		bitIndex := bitNumber - 1
+		if bits[bitIndex/8]&(0x80>>uint(bitIndex%8)) == 0 {
+			continue
+		}
+
+		bitPath := serdes.JoinPath(path, strconv.Itoa(bitNumber))
+		field, exists := bitMapped.Mapping[bitNumber]
+		if !exists || field == nil {
+			return Error{Message: "field not found", Path: bitPath, Cause: serdes.ErrPathNotFound}
+		}
+
+		if err := d.frame(bitPath, field, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func moreBlocks(block []byte, isHex bool) bool {
+	first := block[0]
+	if isHex {
+		decoded := make([]byte, 1)
+		if _, err := hex.Decode(decoded, block[:2]); err != nil {
+			return false
+		}
+		first = decoded[0]
+	}
+	return first&0x80 != 0
+}
+
+// readFull reads size bytes into out, the size is read from the stream so out grows as the bytes arrive.
+func (d *Decoder) readFull(path string, size int, out *bytes.Buffer) error {
+	if size <= 0 {
+		return nil
+	}
+
+	if size > d.maxSize-d.read {
+		return d.tooLong(path)
+	}
+
+	n, err := io.CopyN(out, d.counter(), int64(size))
+	if err == io.EOF && n > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return Error{Message: fmt.Sprintf("error reading %d bytes", size), Path: path, Cause: err}
+	}
+	return nil
+}
+
+func (d *Decoder) tooLong(path string) error {
+	return Error{Message: fmt.Sprintf("message longer than %d bytes", d.maxSize), Path: path, Cause: ErrMessageTooLong}
+}
+
+func (d *Decoder) counter() io.Reader {
+	return readCounter{d}
+}
+
+type readCounter struct {
+	d *Decoder
+}
+
+func (c readCounter) Read(p []byte) (int, error) {
+	n, err := c.d.r.Read(p)
+	c.d.read += n
+	return n, err
+}
+
+// Encoder encodes messages and writes them to a writer.
+type Encoder struct {
+	w      io.Writer
+	serdes serdes.Serdes
+}
+
+func NewEncoder(w io.Writer, s serdes.Serdes) *Encoder {
+	return &Encoder{w: w, serdes: s}
+}
+
+// Encode encodes the value and writes the message.
+func (e *Encoder) Encode(value serdes.Value) error {
+	data, err := e.serdes.Serialize(value)
+	if err != nil {
+		return Error{Message: "error encoding message", Cause: err}
+	}
+
+	if _, err := data.WriteTo(e.w); err != nil {
+		return Error{Message: "error writing message", Cause: err}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package stream_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+	"github.com/mercadolibre/go-iso8583/stream"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	echo = "0800" + "8220000000000000" + "0400000000000000" + "1019120000" + "000123" + "301"
+
+	authorization = "0200" + "7220000000C00000" +
+		"16" + "4761739001010010" +
+		"000000" +
+		"000000001000" +
+		"1019120000" +
+		"000123" +
+		"TERM0001" +
+		"MERCHANT0000001"
+)
+
+func Test_Decoder(t *testing.T) {
+	spec := specs.ISO87ASCII(specs.HexBitmap)
+	decoder := stream.NewDecoder(iotest.OneByteReader(bytes.NewBufferString(echo+authorization)), spec)
+
+	value, err := decoder.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{specs.MTIField: "0800", "7": "1019120000", "11": "000123", "70": "301"}, value)
+
+	value, err = decoder.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{
+		specs.MTIField: "0200",
+		"2":            "4761739001010010",
+		"3":            "000000",
+		"4":            "000000001000",
+		"7":            "1019120000",
+		"11":           "000123",
+		"41":           "TERM0001",
+		"42":           "MERCHANT0000001",
+	}, value)
+
+	_, err = decoder.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func Test_Decoder_Length_Header(t *testing.T) {
+	spec := types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: specs.ISO87ASCII(specs.HexBitmap)}
+	messages := new(bytes.Buffer)
+	encoder := stream.NewEncoder(messages, spec)
+	assert.NoError(t, encoder.Encode(serdes.Map{specs.MTIField: "0800", "7": "1019120000", "11": "000123", "70": "301"}))
+	assert.NoError(t, encoder.Encode(serdes.Map{specs.MTIField: "0810", "7": "1019120000", "11": "000123", "39": "00", "70": "301"}))
+	assert.Equal(t, []byte{0, byte(len(echo))}, messages.Bytes()[:2])
+
+	decoder := stream.NewDecoder(messages, spec)
+	message, err := decoder.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, string([]byte{0, byte(len(echo))})+echo, string(message))
+
+	value, err := decoder.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{specs.MTIField: "0810", "7": "1019120000", "11": "000123", "39": "00", "70": "301"}, value)
+	assert.Equal(t, 0, messages.Len())
+}
+
+func Test_Decoder_Unexpected_EOF(t *testing.T) {
+	spec := specs.ISO87ASCII(specs.HexBitmap)
+	decoder := stream.NewDecoder(bytes.NewBufferString(authorization[:30]), spec)
+
+	_, err := decoder.Decode()
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+	assert.EqualError(t, err, "unexpected end of message: path: 2. -> unexpected EOF")
+}
+
+func Test_Decoder_TLV(t *testing.T) {
+	// the TLV is read until the end of the stream.
+	spec := types.List{Items: []types.Field{
+		{Name: "id", SerDes: types.Byte{}},
+		{Name: "tags", SerDes: types.TLV{Items: []types.Field{{Name: "01", SerDes: types.Ebcdic{}}}}},
+	}}
+	decoder := stream.NewDecoder(bytes.NewBuffer([]byte{0x07, 0xf0, 0xf1, 0xf0, 0xf2, 0xc1, 0xc2}), spec)
+
+	value, err := decoder.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{"id": "7", "tags": serdes.Map{"01": "AB"}}, value)
+
+	_, err = decoder.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func Test_Decoder_Max_Message_Size(t *testing.T) {
+	spec := types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: types.Raw{}}
+
+	// the length header claims more bytes than the maximum, nothing else is read.
+	data := bytes.NewBuffer([]byte{0x01, 0x00, 0xaa, 0xbb})
+	decoder := stream.NewDecoder(data, spec)
+	decoder.SetMaxMessageSize(100)
+	_, err := decoder.Next()
+	assert.True(t, errors.Is(err, stream.ErrMessageTooLong))
+	assert.EqualError(t, err, "message longer than 100 bytes -> message too long")
+	assert.Equal(t, 2, data.Len())
+
+	decoder = stream.NewDecoder(bytes.NewBuffer([]byte{0x00, 0x02, 0xaa, 0xbb}), spec)
+	decoder.SetMaxMessageSize(4)
+	message, err := decoder.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x02, 0xaa, 0xbb}, message)
+
+	// the fields without size are read up to the maximum.
+	tags := types.TLV{Items: []types.Field{{Name: "01", SerDes: types.Ebcdic{}}}}
+	decoder = stream.NewDecoder(bytes.NewBuffer([]byte{0xf0, 0xf1, 0xf0, 0xf2, 0xc1, 0xc2}), tags)
+	decoder.SetMaxMessageSize(5)
+	_, err = decoder.Next()
+	assert.True(t, errors.Is(err, stream.ErrMessageTooLong))
+}
+
+func Test_Encoder_Error(t *testing.T) {
+	encoder := stream.NewEncoder(new(bytes.Buffer), specs.ISO87ASCII(specs.HexBitmap))
+	assert.Error(t, encoder.Encode("0800"))
+}
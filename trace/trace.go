@@ -0,0 +1,222 @@
+// Package trace decodes messages recording the byte range, the raw bytes and the decoded value of every field and
+// subfield, so a malformed message shows the field where the decoding diverged from the spec.
+//
+// The entries are in encoding order, the parents before their subfields, with the value paths used by the other
+// packages: the bit numbers of a BitMapped, the item names of a List and the tags of a TLV or BerTLV. The bitmaps and
+// the length prefixes of VarLength fields have their own entries with the path of their field, the fields of VarLength
+// data and of anonymous List items are entries of the field, example:
+//
+//	value, entries, err := trace.Decode(specs.Mastercard(), data)
+//	for _, entry := range entries {
+//		fmt.Println(entry)
+//	}
+package trace
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+)
+
+// Error is the error of the package, see serdes.Error.
+type Error = serdes.Error
+
+// Entry is a decoded field.
+type Entry struct {
+	// Path is the path of the value of the field, empty for the message.
+	Path string `json:"path"`
+	// Kind is FieldChild for the fields, BitmapChild for the bitmaps and LengthChild for the length prefixes.
+	Kind types.ChildKind `json:"kind"`
+	// Offset is the position of the first byte of the field in the message, the fields of TLV and BerTLV tags start
+	// in the value of the tag.
+	Offset int          `json:"offset"`
+	Length int          `json:"length"`
+	Raw    []byte       `json:"raw"`
+	Value  serdes.Value `json:"value"`
+	// Err is the error decoding the field, the Length of a field that could not be decoded is the number of bytes that
+	// the serdes read before failing. The errors found between fields, like a bit without field, have no length.
+	Err error `json:"error,omitempty"`
+}
+
+func (entry Entry) String() string {
+	path := entry.Path
+	if path == "" {
+		path = "<message>"
+	}
+	if entry.Kind != types.FieldChild {
+		path = fmt.Sprintf("%s (%s)", path, entry.Kind)
+	}
+
+	out := fmt.Sprintf("%06d +%04d %s: %s", entry.Offset, entry.Length, path, hex.EncodeToString(entry.Raw))
+	if entry.Err != nil {
+		return fmt.Sprintf("%s -> error: %v", out, entry.Err)
+	}
+
+	if value, ok := entry.Value.(string); ok {
+		return fmt.Sprintf("%s -> %q", out, value)
+	}
+	return out
+}
+
+type tracer struct {
+	data    []byte
+	entries []Entry
+}
+
+// Decode decodes the message with the spec and returns its value and error, like spec.Deserialize does, with the
+// entries of the fields. When the message cannot be decoded, the entries end in the field that failed.
+func Decode(spec serdes.Serdes, data []byte) (serdes.Value, []Entry, error) {
+	t := &tracer{data: data}
+	t.field("", types.FieldChild, spec, 0, len(data), nil, false)
+	return t.entries[0].Value, t.entries, t.entries[0].Err
+}
+
+// field records the entry of the field from off to end and the entries of its subfields, it returns the end of the
+// field and false when the field or a subfield failed. The field is decoded when its value is not known from the value
+// of its parent, so the bytes of a message are decoded once when no field fails.
+func (t *tracer) field(path string, kind types.ChildKind, s serdes.Serdes, off, end int, value serdes.Value, known bool) (int, bool) {
+	fieldEnd := end
+	var err error
+	if !known {
+		buffer := bytes.NewBuffer(t.data[off:end])
+		value, err = s.Deserialize(buffer)
+		fieldEnd = end - buffer.Len()
+	}
+
+	index := len(t.entries)
+	t.entries = append(t.entries, Entry{Path: path, Kind: kind, Offset: off, Value: value, Err: err})
+
+	// the subfields are traced even when the field failed, so the entries show the subfield that failed.
+	subfieldsEnd, ok := t.subfields(path, s, off, end, value, err == nil)
+	if known {
+		fieldEnd = subfieldsEnd
+	}
+
+	entry := &t.entries[index]
+	entry.Length, entry.Raw = fieldEnd-off, t.data[off:fieldEnd]
+	return fieldEnd, ok && err == nil
+}
+
+// fail records the entry of an error found tracing the subfields, the ones that are not decoded by a serdes.
+func (t *tracer) fail(path string, kind types.ChildKind, off int, err error) (int, bool) {
+	t.entries = append(t.entries, Entry{Path: path, Kind: kind, Offset: off, Raw: []byte{}, Err: err})
+	return off, false
+}
+
+// subfields records the entries of the subfields of the serdes from off to end, it returns their end and false when
+// a subfield failed. The values of the subfields are taken from the value of the serdes when it is known.
+func (t *tracer) subfields(path string, s serdes.Serdes, off, end int, value serdes.Value, known bool) (int, bool) {
+	switch typed := s.(type) {
+	case types.VarLength:
+		return t.varLength(path, typed, off, end, value, known)
+	case types.List:
+		return t.list(path, typed, off, end, value, known)
+	case types.BitMapped:
+		return t.bitMapped(path, typed, off, end, value, known)
+	case types.TLV, types.BerTLV:
+		return t.tags(path, s, off, end, value, known)
+	}
+
+	size, err := types.Scan(s, t.data[off:end])
+	return off + size, err == nil
+}
+
+func (t *tracer) varLength(path string, varLen types.VarLength, off, end int, value serdes.Value, known bool) (int, bool) {
+	lengthEnd, ok := t.field(path, types.LengthChild, varLen.Length, off, end, nil, false)
+	if !ok {
+		return lengthEnd, false
+	}
+
+	dataStart, dataEnd, err := types.ScanVarLength(varLen, t.data[off:end])
+	if err != nil {
+		return t.fail(path, types.FieldChild, lengthEnd, err)
+	}
+
+	if dataStart == dataEnd {
+		return off + dataEnd, true
+	}
+
+	_, ok = t.subfields(path, varLen.Data, off+dataStart, off+dataEnd, value, known)
+	return off + dataEnd, ok
+}
+
+func (t *tracer) list(path string, list types.List, off, end int, value serdes.Value, known bool) (int, bool) {
+	values, _ := value.(serdes.Map)
+	for _, item := range list.Items {
+		if off == end {
+			break
+		}
+
+		ok := true
+		if item.Name == "" {
+			off, ok = t.subfields(path, item.SerDes, off, end, value, known)
+		} else {
+			itemValue, exists := values[item.Name]
+			off, ok = t.field(serdes.JoinPath(path, item.Name), types.FieldChild, item.SerDes, off, end, itemValue, known && exists)
+		}
+		if !ok {
+			return off, false
+		}
+	}
+	return off, true
+}
+
+func (t *tracer) bitMapped(path string, bitMapped types.BitMapped, off, end int, value serdes.Value, known bool) (int, bool) {
+	bits, size, err := types.ScanBitmap(bitMapped.Bitmap, t.data[off:end])
+	if err != nil {
+		if bitMapped.Bitmap.BlockSize <= 0 {
+			return t.fail(path, types.BitmapChild, off, err)
+		}
+		// the bitmap records the error.
+		return t.field(path, types.BitmapChild, bitMapped.Bitmap, off, end, nil, false)
+	}
+
+	off, _ = t.field(path, types.BitmapChild, bitMapped.Bitmap, off, off+size, nil, false)
+	values, _ := value.(serdes.Map)
+	for _, bit := range bits {
+		key := strconv.Itoa(bit)
+		bitPath := serdes.JoinPath(path, key)
+		fieldSerdes := bitMapped.Mapping[bit]
+		if fieldSerdes == nil {
+			return t.fail(bitPath, types.FieldChild, off, Error{Message: "field not found", Path: bitPath, Cause: serdes.ErrPathNotFound})
+		}
+
+		fieldValue, exists := values[key]
+		var ok bool
+		if off, ok = t.field(bitPath, types.FieldChild, fieldSerdes, off, end, fieldValue, known && exists); !ok {
+			return off, false
+		}
+	}
+	return off, true
+}
+
+// tags records the entries of the tags of a TLV or BerTLV, see types.ScanTags. The tags that the BerTLV drops are
+// recorded as Raw values.
+func (t *tracer) tags(path string, s serdes.Serdes, off, end int, value serdes.Value, known bool) (int, bool) {
+	tags, err := types.ScanTags(s, t.data[off:end])
+	if err != nil {
+		var deserializationErr types.DeserializationError
+		errors.As(err, &deserializationErr)
+		return t.fail(path, types.FieldChild, off+deserializationErr.Offset, err)
+	}
+
+	values, _ := value.(serdes.Map)
+	for _, tag := range tags {
+		tagSerdes := tag.SerDes
+		if tagSerdes == nil {
+			tagSerdes = types.Raw{}
+		}
+
+		tagValue, exists := values[tag.Key]
+		tagPath := serdes.JoinPath(path, tag.Key)
+		if _, ok := t.field(tagPath, types.FieldChild, tagSerdes, off+tag.Value, off+tag.End, tagValue, known && exists); !ok {
+			return off + tag.End, false
+		}
+	}
+	return end, true
+}
@@ -0,0 +1,128 @@
+package trace_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/internal/testspec"
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/trace"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type entry struct {
+	path   string
+	kind   types.ChildKind
+	offset int
+	length int
+	value  serdes.Value
+}
+
+func entries(traced []trace.Entry) []entry {
+	var out []entry
+	for _, e := range traced {
+		out = append(out, entry{e.Path, e.Kind, e.Offset, e.Length, e.Value})
+	}
+	return out
+}
+
+func Test_Decode(t *testing.T) {
+	message := testspec.Message()
+	data, err := testspec.Spec().Serialize(message)
+	assert.NoError(t, err)
+
+	value, traced, err := trace.Decode(testspec.Spec(), data.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+	assert.Equal(t, []entry{
+		{"", types.FieldChild, 0, 102, message},
+		{"mti", types.FieldChild, 0, 4, "0100"},
+		{"", types.BitmapChild, 4, 32, []byte{0x60, 0, 0, 0x01, 0, 0x01, 0x02, 0, 0x04, 0, 0, 0, 0, 0, 0, 0}},
+		{"2", types.FieldChild, 36, 9, "4761739001010010"},
+		{"2", types.LengthChild, 36, 1, "16"},
+		{"3", types.FieldChild, 45, 6, "000000"},
+		{"32", types.FieldChild, 51, 8, "123456"},
+		{"32", types.LengthChild, 51, 2, "06"},
+		{"48", types.FieldChild, 59, 22, message["48"]},
+		{"48", types.LengthChild, 59, 3, "019"},
+		{"48.tcc", types.FieldChild, 62, 1, "R"},
+		{"48.se", types.FieldChild, 63, 18, serdes.Map{"21": "01010", "61": "00001"}},
+		{"48.se.21", types.FieldChild, 67, 5, "01010"},
+		{"48.se.61", types.FieldChild, 76, 5, "00001"},
+		{"55", types.FieldChild, 81, 18, message["55"]},
+		{"55", types.LengthChild, 81, 2, "16"},
+		{"55.9f26", types.FieldChild, 86, 8, "0123456789abcdef"},
+		{"55.9f36", types.FieldChild, 97, 2, "0001"},
+		{"70", types.FieldChild, 99, 3, "301"},
+	}, entries(traced))
+
+	assert.Equal(t, "000036 +0001 2 (length): 10 -> \"16\"", traced[4].String())
+	assert.Equal(t, "000000 +0004 mti: 30313030 -> \"0100\"", traced[1].String())
+}
+
+func Test_Decode_Malformed(t *testing.T) {
+	message := serdes.Map{"mti": "0100", "3": "000000", "48": serdes.Map{"tcc": "R", "se": serdes.Map{"21": "A"}}}
+	data, err := testspec.Spec().Serialize(message)
+	assert.NoError(t, err)
+
+	// the length of the tag 21 is bigger than the field 48.
+	malformed := append([]byte{}, data.Bytes()...)
+	malformed[len(malformed)-2] = 0xf9
+
+	_, expectedErr := testspec.Spec().Deserialize(bytes.NewBuffer(malformed))
+	_, traced, err := trace.Decode(testspec.Spec(), malformed)
+	assert.Equal(t, expectedErr, err)
+
+	last := traced[len(traced)-1]
+	assert.Equal(t, "48.se", last.Path)
+	assert.Equal(t, 30, last.Offset)
+	assert.True(t, errors.Is(last.Err, types.ErrShortBuffer))
+
+	// the bit 4 has no field.
+	malformed = append([]byte{}, data.Bytes()...)
+	malformed[4] = '3'
+	_, traced, err = trace.Decode(testspec.Spec(), malformed)
+	assert.True(t, errors.Is(err, types.ErrUnknownBit))
+	assert.Equal(t, "000026 +0000 4:  -> error: field not found: path: 4. -> path not found", traced[len(traced)-1].String())
+	assert.EqualError(t, traced[len(traced)-1].Err, "field not found: path: 4. -> path not found")
+
+	// the message ends in the field 3.
+	_, traced, err = trace.Decode(testspec.Spec(), data.Bytes()[:23])
+	assert.Error(t, err)
+	last = traced[len(traced)-1]
+	assert.Equal(t, "3", last.Path)
+	assert.Equal(t, 20, last.Offset)
+	assert.Error(t, last.Err)
+}
+
+// counter counts the values it decodes.
+type counter struct {
+	types.Ascii
+	calls *int
+}
+
+func (c counter) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	*c.calls++
+	return c.Ascii.Deserialize(data)
+}
+
+func Test_Decode_Once(t *testing.T) {
+	calls := 0
+	nested := types.List{Items: []types.Field{
+		{Name: "outer", SerDes: types.VarLength{Length: types.Byte{}, Data: types.List{Items: []types.Field{
+			{Name: "inner", SerDes: types.VarLength{Length: types.Byte{}, Data: types.List{Items: []types.Field{
+				{Name: "leaf", SerDes: counter{Ascii: types.Ascii{NumDigits: 2}, calls: &calls}},
+			}}}},
+		}}}},
+	}}
+
+	value, traced, err := trace.Decode(nested, []byte{3, 2, 'O', 'K'})
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{"outer": serdes.Map{"inner": serdes.Map{"leaf": "OK"}}}, value)
+	assert.Len(t, traced, 6)
+	assert.Equal(t, "OK", traced[len(traced)-1].Value)
+	assert.Equal(t, 1, calls)
+}
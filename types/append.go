@@ -0,0 +1,81 @@
+package types
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+)
+
+// appendSerializer is implemented by the types that serialize through AppendSerialize.
+type appendSerializer interface {
+	AppendSerialize(dst []byte, value serdes.Value) ([]byte, error)
+}
+
+// serialize returns the value encoded by AppendSerialize in a new buffer.
+func serialize(s appendSerializer, value serdes.Value) (*bytes.Buffer, error) {
+	data, err := s.AppendSerialize(nil, value)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(data), nil
+}
+
+// grow extends dst with n zero bytes.
+func grow(dst []byte, n int) []byte {
+	for i := 0; i < n; i++ {
+		dst = append(dst, 0)
+	}
+	return dst
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// moveBefore moves the bytes appended after mid before dst[start:mid], used to encode the prefixes whose value depends
+// on the encoded data: the prefix is appended after the data and rotated in place with three reversals.
+func moveBefore(dst []byte, start, mid int) {
+	reverse(dst[start:mid])
+	reverse(dst[mid:])
+	reverse(dst[start:])
+}
+
+func reverse(data []byte) {
+	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+}
+
+// decimals are the decimal strings of the lengths as values, so the lengths are serialized without converting them.
+var decimals = func() []serdes.Value {
+	values := make([]serdes.Value, 1000)
+	for i := range values {
+		values[i] = strconv.Itoa(i)
+	}
+	return values
+}()
+
+// decimal returns the decimal string of n as a value.
+func decimal(n int) serdes.Value {
+	if n >= 0 && n < len(decimals) {
+		return decimals[n]
+	}
+	return strconv.Itoa(n)
+}
+
+// clearMap deletes all the values of the map, so it can be reused.
+func clearMap(m serdes.Map) {
+	for key := range m {
+		delete(m, key)
+	}
+}
+
+// intoDeserializer is implemented by the types that decode a map into an existing one without clearing it, so the
+// anonymous items of a List are decoded directly into the List map.
+type intoDeserializer interface {
+	deserializeInto(data *bytes.Buffer, dst serdes.Map) error
+}
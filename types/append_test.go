@@ -0,0 +1,226 @@
+package types_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appendSpec() types.List {
+	return types.List{Items: []types.Field{
+		{Name: "mti", SerDes: types.Bcd{NumDigits: 4, NotPadded: true}},
+		{SerDes: types.BitMapped{
+			Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128},
+			Mapping: map[int]serdes.Serdes{
+				2:  types.VarLength{Length: types.Byte{}, Data: types.Bcd{}},
+				3:  types.Bcd{NumDigits: 6, NotPadded: true},
+				4:  types.Bcd{NumDigits: 12, NotPadded: true},
+				11: types.Bcd{NumDigits: 6, NotPadded: true},
+				37: types.Ebcdic{NumDigits: 12},
+				41: types.Ebcdic{NumDigits: 8},
+				43: types.AsciiNumeric{NumDigits: 4},
+				48: types.VarLength{Length: types.EbcdicNumeric{NumDigits: 3}, Data: types.List{Items: []types.Field{
+					{Name: "tcc", SerDes: types.Ebcdic{NumDigits: 1}},
+					{Name: "se", SerDes: types.TLV{Items: []types.Field{
+						{Name: "21", SerDes: types.Ebcdic{}},
+						{Name: "61", SerDes: types.EbcdicNumeric{}},
+					}}},
+				}}},
+				55: types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: types.BerTLV{Items: []types.Field{
+					{Name: "9f26", SerDes: types.Raw{}},
+					{Name: "9f36", SerDes: types.Raw{}},
+				}}},
+				70: types.Ascii{NumDigits: 3},
+				96: types.Raw{NumBytes: 8},
+			},
+		}},
+	}}
+}
+
+func appendMessage() serdes.Map {
+	return serdes.Map{
+		"mti": "0100",
+		"2":   "4761739001010010",
+		"3":   "000000",
+		"4":   "000000001000",
+		"11":  "000123",
+		"37":  "123456789012",
+		"41":  "TERM0001",
+		"43":  "0042",
+		"48":  serdes.Map{"tcc": "R", "se": serdes.Map{"21": "01010", "61": "00001"}},
+		"55":  serdes.Map{"9f26": "0123456789abcdef", "9f36": "0001"},
+		"70":  "301",
+		"96":  "0011223344556677",
+	}
+}
+
+func Test_AppendSerialize(t *testing.T) {
+	spec := appendSpec()
+	message := appendMessage()
+
+	buffer, err := spec.Serialize(message)
+	assert.NoError(t, err)
+
+	data, err := spec.AppendSerialize([]byte("prefix"), message)
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte("prefix"), buffer.Bytes()...), data)
+
+	value, err := spec.Deserialize(bytes.NewBuffer(data[len("prefix"):]))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+
+	_, err = spec.AppendSerialize(nil, serdes.Map{"mti": "0100", "3": "abc"})
+	assert.EqualError(t, err, "field serializer failed: serializer: list. field name:  - field serdes name: bitMapped. value type: map[string]interface {}. -> serializer failed: serializer: bitMapped. field name: 3 - field serdes name: bcd. value type: string. -> for bcd type, just numbers are allowed in string: serializer: bcd. value type: string.")
+}
+
+func Test_AppendSerialize_Leaves(t *testing.T) {
+	tests := []struct {
+		serdes types.Introspectable
+		value  serdes.Value
+	}{
+		{types.Ascii{NumDigits: 5}, "ab"},
+		{types.AsciiNumeric{NumDigits: 5}, "12"},
+		{types.Ebcdic{NumDigits: 5}, "ab"},
+		{types.EbcdicNumeric{NumDigits: 5}, "12"},
+		{types.Bcd{NumDigits: 5}, "123"},
+		{types.Raw{}, "0a0b"},
+		{types.Byte{}, "7"},
+		{types.Word{Order: binary.LittleEndian}, "513"},
+		{types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true}, []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0x80}},
+		{types.VarLength{Length: types.AsciiNumeric{NumDigits: 2}, Data: types.Ascii{}}, "hello"},
+	}
+
+	for _, tt := range tests {
+		buffer, err := tt.serdes.Serialize(tt.value)
+		assert.NoError(t, err, tt.serdes.Name())
+
+		data, err := serdes.AppendSerialize([]byte{0xff}, tt.serdes, tt.value)
+		assert.NoError(t, err, tt.serdes.Name())
+		assert.Equal(t, append([]byte{0xff}, buffer.Bytes()...), data, tt.serdes.Name())
+	}
+}
+
+func Test_AppendSerialize_Allocs(t *testing.T) {
+	spec := appendSpec()
+	message := appendMessage()
+	dst, err := spec.AppendSerialize(nil, message)
+	assert.NoError(t, err)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		dst, _ = spec.AppendSerialize(dst[:0], message)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func Test_AppendSerialize_Error_Returns_Dst(t *testing.T) {
+	tests := []struct {
+		serdes serdes.Serdes
+		value  serdes.Value
+	}{
+		{types.Ascii{NumDigits: 1}, "ab"},
+		{types.AsciiNumeric{}, 1},
+		{types.Ebcdic{}, "a€"},
+		{types.EbcdicNumeric{NumDigits: 5}, "12€"},
+		{types.Bcd{}, "1a"},
+		{types.Raw{}, "0a0g"},
+		{types.Byte{}, "a"},
+		{types.Word{}, "1"},
+		{types.Bitmap{}, "1"},
+		{types.VarLength{Length: types.AsciiNumeric{NumDigits: 1}, Data: types.Ascii{}}, "0123456789"},
+		{appendSpec(), serdes.Map{"mti": "0100", "3": "000000", "70": "toolong"}},
+		{types.TLV{Items: []types.Field{{Name: "21", SerDes: types.Ascii{}}, {Name: "61", SerDes: types.Raw{}}}}, serdes.Map{"21": "a", "61": "x"}},
+		{types.BerTLV{Items: []types.Field{{Name: "9f36", SerDes: types.Raw{}}, {Name: "1f", SerDes: types.Raw{}}}}, serdes.Map{"9f36": "0001", "1f": "00"}},
+	}
+
+	for _, tt := range tests {
+		dst := []byte("prefix")
+		data, err := serdes.AppendSerialize(dst, tt.serdes, tt.value)
+		assert.Error(t, err, tt.serdes.Name())
+		assert.Equal(t, []byte("prefix"), data, tt.serdes.Name())
+	}
+}
+
+func Test_Bitmap_Serialize_Does_Not_Modify_Value(t *testing.T) {
+	value := []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0x80}
+	buffer, err := types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true}.Serialize(value)
+	assert.NoError(t, err)
+	assert.Equal(t, "81000000000000008000000000000000", buffer.String())
+	assert.Equal(t, []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0x80}, value)
+}
+
+func Test_DeserializeInto(t *testing.T) {
+	spec := appendSpec()
+	data, err := spec.AppendSerialize(nil, appendMessage())
+	assert.NoError(t, err)
+
+	dst := serdes.Map{"stale": "value", "mti": "0800"}
+	assert.NoError(t, spec.DeserializeInto(bytes.NewBuffer(data), dst))
+	assert.Equal(t, appendMessage(), dst)
+
+	echo, err := spec.Serialize(serdes.Map{"mti": "0800", "11": "000001", "70": "301"})
+	assert.NoError(t, err)
+	assert.NoError(t, serdes.DeserializeInto(echo, spec, dst))
+	assert.Equal(t, serdes.Map{"mti": "0800", "11": "000001", "70": "301"}, dst)
+
+	se := serdes.Map{}
+	tlv := types.VarLength{Length: types.Byte{}, Data: types.TLV{Items: []types.Field{{Name: "21", SerDes: types.Ebcdic{}}}}}
+	assert.NoError(t, tlv.DeserializeInto(bytes.NewBuffer([]byte{0x05, 0xf2, 0xf1, 0xf0, 0xf1, 0xc1}), se))
+	assert.Equal(t, serdes.Map{"21": "A"}, se)
+
+	assert.Equal(t, serdes.ErrNotMap, serdes.DeserializeInto(bytes.NewBuffer([]byte{0x01}), types.Byte{}, serdes.Map{}))
+}
+
+func Benchmark_Serialize(b *testing.B) {
+	spec := appendSpec()
+	message := appendMessage()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := spec.Serialize(message); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_AppendSerialize(b *testing.B) {
+	spec := appendSpec()
+	message := appendMessage()
+	var dst []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err error
+		if dst, err = spec.AppendSerialize(dst[:0], message); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Deserialize(b *testing.B) {
+	spec := appendSpec()
+	data, _ := spec.AppendSerialize(nil, appendMessage())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := spec.Deserialize(bytes.NewBuffer(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_DeserializeInto(b *testing.B) {
+	spec := appendSpec()
+	data, _ := spec.AppendSerialize(nil, appendMessage())
+	dst := serdes.Map{}
+	buffer := new(bytes.Buffer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buffer.Reset()
+		buffer.Write(data)
+		if err := spec.DeserializeInto(buffer, dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
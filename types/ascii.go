@@ -0,0 +1,98 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+)
+
+type Ascii struct {
+	Desc
+	Mask
+	NumDigits int
+}
+
+func (ascii Ascii) Name() string {
+	return "ascii"
+}
+
+func (ascii Ascii) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(ascii, value)
+}
+
+// AppendSerialize appends the value left justified and padded with spaces.
+func (ascii Ascii) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	valueStr, ok := value.(string)
+	if !ok {
+		return dst, SerializerError{
+			Message: "invalid value type", Serdes: ascii, Value: ascii.MaskValue(value),
+		}
+	}
+
+	valueLen := len(valueStr)
+	numDigits := ascii.NumDigits
+	if numDigits == 0 {
+		numDigits = valueLen
+	}
+
+	if ascii.NumDigits > 0 && valueLen > numDigits {
+		return dst, SerializerError{
+			Message: "value too long", Serdes: ascii, Value: ascii.MaskValue(value), Err: ErrValueTooLong,
+		}
+	}
+
+	dst = append(dst, valueStr...)
+	for count := utf8.RuneCountInString(valueStr); count < numDigits; count++ {
+		dst = append(dst, ' ')
+	}
+	return dst, nil
+}
+
+// Size returns the number of bytes of the value padded to NumDigits characters.
+func (ascii Ascii) Size(value serdes.Value) (int, error) {
+	valueStr, ok := value.(string)
+	if !ok {
+		return 0, SerializerError{
+			Message: "invalid value type", Serdes: ascii, Value: ascii.MaskValue(value),
+		}
+	}
+
+	if ascii.NumDigits > 0 && len(valueStr) > ascii.NumDigits {
+		return 0, SerializerError{
+			Message: "value too long", Serdes: ascii, Value: ascii.MaskValue(value), Err: ErrValueTooLong,
+		}
+	}
+
+	numDigits := ascii.NumDigits
+	if numDigits == 0 {
+		numDigits = len(valueStr)
+	}
+	return len(valueStr) + maxInt(numDigits-utf8.RuneCountInString(valueStr), 0), nil
+}
+
+func (ascii Ascii) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	numDigits := ascii.NumDigits
+	if numDigits == 0 {
+		numDigits = data.Len()
+	}
+
+	if data.Len() < numDigits {
+		return nil, DeserializationError{
+			Message: "data does not has bytes enough", Serdes: ascii, Remaning: data.Len(),
+		}.shortBuffer(numDigits, data.Len())
+	}
+
+	out := string(data.Next(numDigits))
+	out = strings.TrimRight(out, " ")
+	return out, nil
+}
+
+func (ascii Ascii) Children() []Child {
+	return nil
+}
+
+func (ascii Ascii) FixedSize() (int, bool) {
+	return fixedDigits(ascii.NumDigits)
+}
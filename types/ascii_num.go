@@ -0,0 +1,129 @@
+package types
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+)
+
+type AsciiNumeric struct {
+	Desc
+	Mask
+	NumDigits int
+}
+
+func (ascii AsciiNumeric) Name() string {
+	return "ascii_numeric"
+}
+
+func (ascii AsciiNumeric) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(ascii, value)
+}
+
+// AppendSerialize appends the value right justified and padded with zeros.
+func (ascii AsciiNumeric) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	valueStr, ok := value.(string)
+	if !ok {
+		return dst, SerializerError{
+			Message: "invalid value type", Serdes: ascii, Value: ascii.MaskValue(value),
+		}
+	}
+	return ascii.appendString(dst, valueStr)
+}
+
+// appendString appends the string like AppendSerialize, without converting it to a serdes.Value.
+func (ascii AsciiNumeric) appendString(dst []byte, valueStr string) ([]byte, error) {
+	valueLen := len(valueStr)
+	numDigits := ascii.NumDigits
+	if numDigits == 0 {
+		numDigits = valueLen
+	}
+
+	if ascii.NumDigits > 0 && valueLen > numDigits {
+		return dst, SerializerError{
+			Message: "value too long", Serdes: ascii, Value: ascii.MaskValue(valueStr), Err: ErrValueTooLong,
+		}
+	}
+
+	if !isDigits(valueStr) {
+		return dst, SerializerError{
+			Message: "invalid value", Serdes: ascii, Value: ascii.MaskValue(valueStr), Err: ErrInvalidCharacter,
+		}
+	}
+
+	for count := utf8.RuneCountInString(valueStr); count < numDigits; count++ {
+		dst = append(dst, '0')
+	}
+	return append(dst, valueStr...), nil
+}
+
+// Size returns the number of bytes of the value padded to NumDigits characters.
+func (ascii AsciiNumeric) Size(value serdes.Value) (int, error) {
+	valueStr, ok := value.(string)
+	if !ok {
+		return 0, SerializerError{
+			Message: "invalid value type", Serdes: ascii, Value: ascii.MaskValue(value),
+		}
+	}
+
+	if ascii.NumDigits > 0 && len(valueStr) > ascii.NumDigits {
+		return 0, SerializerError{
+			Message: "value too long", Serdes: ascii, Value: ascii.MaskValue(value), Err: ErrValueTooLong,
+		}
+	}
+
+	if !isDigits(valueStr) {
+		return 0, SerializerError{
+			Message: "invalid value", Serdes: ascii, Value: ascii.MaskValue(value), Err: ErrInvalidCharacter,
+		}
+	}
+
+	numDigits := ascii.NumDigits
+	if numDigits == 0 {
+		numDigits = len(valueStr)
+	}
+	return len(valueStr) + maxInt(numDigits-utf8.RuneCountInString(valueStr), 0), nil
+}
+
+func (ascii AsciiNumeric) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	numDigits := ascii.NumDigits
+	if numDigits == 0 {
+		numDigits = data.Len()
+	}
+
+	if data.Len() < numDigits {
+		return nil, DeserializationError{
+			Message: "data does not has bytes enough", Serdes: ascii, Remaning: data.Len(),
+		}.shortBuffer(numDigits, data.Len())
+	}
+
+	digits := data.Next(numDigits)
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, DeserializationError{
+				Message: "data is not numeric", Serdes: ascii, Remaning: data.Len(), Err: ErrInvalidCharacter,
+			}
+		}
+	}
+
+	return string(digits), nil
+}
+
+// isDigits reports whether the value only has the digits 0 to 9.
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (ascii AsciiNumeric) Children() []Child {
+	return nil
+}
+
+func (ascii AsciiNumeric) FixedSize() (int, bool) {
+	return fixedDigits(ascii.NumDigits)
+}
@@ -0,0 +1,71 @@
+package types_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AsciiNumeric_Serialize_Fixed_Size(t *testing.T) {
+	ser := types.AsciiNumeric{NumDigits: 12}
+
+	buffer, err := ser.Serialize("1500")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("000000001500"), buffer.Bytes())
+	assert.Equal(t, "ascii_numeric", ser.Name())
+}
+
+func Test_AsciiNumeric_Serialize_Var_Size(t *testing.T) {
+	ser := types.AsciiNumeric{}
+
+	buffer, err := ser.Serialize("1500")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1500"), buffer.Bytes())
+}
+
+func Test_AsciiNumeric_Serialize_Errors(t *testing.T) {
+	ser := types.AsciiNumeric{}
+
+	_, err := ser.Serialize(1500)
+	assert.Error(t, err)
+
+	ser = types.AsciiNumeric{NumDigits: 2}
+	_, err = ser.Serialize("1500")
+	assert.Error(t, err)
+}
+
+func Test_AsciiNumeric_Deserialize_Success(t *testing.T) {
+	des := types.AsciiNumeric{NumDigits: 6}
+	data := bytes.NewBufferString("000042123")
+
+	value, err := des.Deserialize(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "000042", value)
+	assert.Equal(t, "123", data.String())
+}
+
+func Test_AsciiNumeric_Deserialize_Error(t *testing.T) {
+	des := types.AsciiNumeric{NumDigits: 6}
+
+	_, err := des.Deserialize(bytes.NewBufferString("0042"))
+	assert.Error(t, err)
+}
+
+func Test_AsciiNumeric_Invalid_Character(t *testing.T) {
+	ser := types.AsciiNumeric{Mask: types.MaskAll, NumDigits: 6}
+
+	_, err := ser.Serialize("abcdef")
+	assert.ErrorIs(t, err, types.ErrInvalidCharacter)
+	var serErr types.SerializerError
+	assert.ErrorAs(t, err, &serErr)
+	assert.Equal(t, "******", serErr.Value)
+
+	_, err = ser.Size("02X0")
+	assert.ErrorIs(t, err, types.ErrInvalidCharacter)
+
+	_, err = ser.Deserialize(bytes.NewBufferString("00004a"))
+	assert.ErrorIs(t, err, types.ErrInvalidCharacter)
+}
@@ -0,0 +1,59 @@
+package types_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Ascii_Serialize_Fixed_Size(t *testing.T) {
+	ser := types.Ascii{NumDigits: 10}
+
+	buffer, err := ser.Serialize("TERM01")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("TERM01    "), buffer.Bytes())
+	assert.Equal(t, "ascii", ser.Name())
+}
+
+func Test_Ascii_Serialize_Var_Size(t *testing.T) {
+	ser := types.Ascii{}
+
+	buffer, err := ser.Serialize("TERM01")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("TERM01"), buffer.Bytes())
+}
+
+func Test_Ascii_Serialize_Errors(t *testing.T) {
+	ser := types.Ascii{}
+
+	_, err := ser.Serialize(332131)
+	assert.Error(t, err)
+
+	ser = types.Ascii{NumDigits: 4}
+	_, err = ser.Serialize("TERM01")
+	assert.Error(t, err)
+}
+
+func Test_Ascii_Deserialize_Success(t *testing.T) {
+	des := types.Ascii{NumDigits: 10}
+	data := bytes.NewBufferString("TERM01    NEXT")
+
+	value, err := des.Deserialize(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "TERM01", value)
+	assert.Equal(t, "NEXT", data.String())
+
+	value, err = types.Ascii{}.Deserialize(bytes.NewBufferString("TERM01  "))
+	assert.NoError(t, err)
+	assert.Equal(t, "TERM01", value)
+}
+
+func Test_Ascii_Deserialize_Error(t *testing.T) {
+	des := types.Ascii{NumDigits: 10}
+
+	_, err := des.Deserialize(bytes.NewBufferString("TERM"))
+	assert.Error(t, err)
+}
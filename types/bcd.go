@@ -10,6 +10,7 @@ import (
 
 type Bcd struct {
 	Desc
+	Mask
 	NumDigits int
 	NotPadded bool
 }
@@ -19,22 +20,36 @@ func (bcd Bcd) Name() string {
 }
 
 func (bcd Bcd) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(bcd, value)
+}
+
+// AppendSerialize appends the digits packed two per byte, right justified and padded with zeros.
+func (bcd Bcd) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
 	valueStr, err := bcd.normalizeValue(value)
 	if err != nil {
-		return nil, err
+		return dst, err
 	}
 
 	numDigits := len(valueStr)
 	if numDigits, err = bcd.normalizeNumDigits(value, numDigits); err != nil {
-		return nil, err
+		return dst, err
 	}
 
-	if numDigits == 0 {
-		return &bytes.Buffer{}, nil
+	return bcd.appendNumToBcd(dst, valueStr, numDigits), nil
+}
+
+// Size returns the number of bytes of the digits, the odd number of digits are padded to a byte.
+func (bcd Bcd) Size(value serdes.Value) (int, error) {
+	valueStr, err := bcd.normalizeValue(value)
+	if err != nil {
+		return 0, err
 	}
 
-	raw := bcd.convertNumToBcd(valueStr, numDigits)
-	return bytes.NewBuffer(raw), nil
+	numDigits, err := bcd.normalizeNumDigits(value, len(valueStr))
+	if err != nil {
+		return 0, err
+	}
+	return numDigits / 2, nil
 }
 
 func (bcd Bcd) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
@@ -53,7 +68,7 @@ func (bcd Bcd) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	if data.Len() < numBytes {
 		return nil, DeserializationError{
 			Message: "data does not has bytes enough", Serdes: bcd, Remaning: data.Len(),
-		}
+		}.shortBuffer(numBytes, data.Len())
 	}
 
 	raw := data.Next(numBytes)
@@ -69,11 +84,12 @@ func (bcd Bcd) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	return value, nil
 }
 
-func (bcd Bcd) convertNumToBcd(valueStr string, numDigits int) []byte {
-	numBytes := numDigits / 2
+func (bcd Bcd) appendNumToBcd(dst []byte, valueStr string, numDigits int) []byte {
+	start := len(dst)
+	dst = grow(dst, numDigits/2)
+	data := dst[start:]
 	valueLen := len(valueStr)
 
-	data := make([]byte, numBytes)
 	for indexDigit := 0; indexDigit < numDigits; indexDigit++ {
 		var digit int
 		valueIndex := indexDigit - (numDigits - valueLen)
@@ -94,14 +110,14 @@ func (bcd Bcd) convertNumToBcd(valueStr string, numDigits int) []byte {
 		nibbleOffset := 4 * ((indexDigit + 1) % 2)
 		data[indexDigit/2] |= byte(digit << nibbleOffset)
 	}
-	return data
+	return dst
 }
 
 func (bcd Bcd) normalizeValue(value serdes.Value) (string, error) {
 	valueStr, ok := value.(string)
 	if !ok {
 		return "", SerializerError{
-			Message: "invalid value type", Serdes: bcd, Value: value,
+			Message: "invalid value type", Serdes: bcd, Value: bcd.MaskValue(value),
 		}
 	}
 
@@ -111,7 +127,7 @@ func (bcd Bcd) normalizeValue(value serdes.Value) (string, error) {
 		}
 		if c < '0' || c > '9' {
 			return "", SerializerError{
-				Message: "for bcd type, just numbers are allowed in string", Serdes: bcd, Value: value,
+				Message: "for bcd type, just numbers are allowed in string", Serdes: bcd, Value: bcd.MaskValue(value), Err: ErrInvalidCharacter,
 			}
 		}
 	}
@@ -122,7 +138,7 @@ func (bcd Bcd) normalizeValue(value serdes.Value) (string, error) {
 func (bcd Bcd) normalizeNumDigits(value serdes.Value, numDigits int) (int, error) {
 	if bcd.NumDigits > 0 && numDigits > bcd.NumDigits {
 		return 0, SerializerError{
-			Message: "value too long", Serdes: bcd, Value: value,
+			Message: "value too long", Serdes: bcd, Value: bcd.MaskValue(value), Err: ErrValueTooLong,
 		}
 	}
 
@@ -136,3 +152,11 @@ func (bcd Bcd) normalizeNumDigits(value serdes.Value, numDigits int) (int, error
 
 	return numDigits, nil
 }
+
+func (bcd Bcd) Children() []Child {
+	return nil
+}
+
+func (bcd Bcd) FixedSize() (int, bool) {
+	return (bcd.NumDigits + 1) / 2, bcd.NumDigits > 0
+}
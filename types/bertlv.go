@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -34,19 +35,24 @@ func (t BerTLV) Name() string {
 }
 
 func (t BerTLV) Serialize(data serdes.Value) (*bytes.Buffer, error) {
+	return serialize(t, data)
+}
+
+// AppendSerialize appends the listed items, the tag and length of each item are inserted once its value is encoded.
+func (t BerTLV) AppendSerialize(dst []byte, data serdes.Value) ([]byte, error) {
 	mapValue, ok := data.(serdes.Map)
 	if !ok {
-		return nil, SerializerError{
-			Message: fmt.Sprintf("invalid value [%T], expected: %T", data, serdes.Map{}), Value: data, Serdes: t,
+		return dst, SerializerError{
+			Message: fmt.Sprintf("invalid value [%T], expected: %T", data, serdes.Map{}), Value: maskValue(t, data), Serdes: t,
 		}
 	}
 
-	serializedData := new(bytes.Buffer)
+	start := len(dst)
 	for _, field := range t.Items {
 		if field.Name == "" {
-			return nil, SerializerError{
+			return dst[:start], SerializerError{
 				Message: "field name not found", Serdes: t, Field: field,
-			}
+			}.within(field.Name)
 		}
 
 		itemValue, ok := mapValue[field.Name]
@@ -54,68 +60,112 @@ func (t BerTLV) Serialize(data serdes.Value) (*bytes.Buffer, error) {
 			continue
 		}
 
-		data, err := field.SerDes.Serialize(itemValue)
+		itemStart := len(dst)
+		encoded, err := serdes.AppendSerialize(dst, field.SerDes, itemValue)
 		if err != nil {
-			return nil, SerializerError{
+			return dst[:start], SerializerError{
 				Message: "value serializer failed", Serdes: t, Field: field, Cause: err,
-			}
+			}.within(field.Name)
 		}
 
-		tagRaw, err := Raw{}.Serialize(field.Name)
+		valueEnd := len(encoded)
+		encoded, err = Raw{}.appendString(encoded, field.Name)
 		if err != nil {
-			return nil, SerializerError{
+			return dst[:start], SerializerError{
 				Message: "tag serializer failed", Serdes: t, Field: field, Cause: err,
-			}
+			}.within(field.Name)
 		}
 
-		tag, _, err := readTag(bytes.NewReader(tagRaw.Bytes()))
+		tag, _, err := parseTag(encoded[valueEnd:])
 		if err != nil {
-			return nil, SerializerError{
-				Message: "invalid tag", Serdes: t, Field: field, Value: tagRaw, Cause: err,
-			}
+			tagRaw := append([]byte(nil), encoded[valueEnd:]...)
+			return dst[:start], SerializerError{
+				Message: "invalid tag", Serdes: t, Field: field, Value: bytes.NewBuffer(tagRaw), Cause: err,
+			}.within(field.Name)
 		}
 
-		builtTlv := TagValue{
-			Tag:     tag,
-			SizeLen: t.SizeLen,
-			Value:   data.Bytes(),
-		}
+		// the tag and the length are appended after the value and moved before it.
+		builtTlv := TagValue{Tag: tag, SizeLen: t.SizeLen}
+		encoded = builtTlv.appendLen(builtTlv.appendTag(encoded[:valueEnd]), valueEnd-itemStart)
+		moveBefore(encoded, itemStart, valueEnd)
+		dst = encoded
+	}
+
+	return dst, nil
+}
 
-		if _, err := serializedData.ReadFrom(builtTlv.bufferBytes()); err != nil {
-			return nil, SerializerError{
-				Message: "data struct serializer failed", Value: data, Serdes: t, Cause: err,
-			}
+// Size returns the size of the tag, length and value of the listed items.
+func (t BerTLV) Size(data serdes.Value) (int, error) {
+	mapValue, ok := data.(serdes.Map)
+	if !ok {
+		return 0, SerializerError{
+			Message: fmt.Sprintf("invalid value [%T], expected: %T", data, serdes.Map{}), Value: maskValue(t, data), Serdes: t,
 		}
 	}
 
-	return serializedData, nil
-}
+	size := 0
+	for _, field := range t.Items {
+		if field.Name == "" {
+			return 0, SerializerError{
+				Message: "field name not found", Serdes: t, Field: field,
+			}.within(field.Name)
+		}
 
-func (t BerTLV) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
-	listValues := serdes.Map{}
+		itemValue, ok := mapValue[field.Name]
+		if !ok {
+			continue
+		}
 
-	mapTLV, err := decode(t.SizeLen, data.Bytes())
-	if err != nil {
-		return nil, DeserializationError{
-			Message: "data struct deserializer failed", Serdes: t, Remaning: data.Len(), Cause: err,
+		valueSize, err := serdes.Size(field.SerDes, itemValue)
+		if err != nil {
+			return 0, SerializerError{
+				Message: "value serializer failed", Serdes: t, Field: field, Cause: err,
+			}.within(field.Name)
 		}
-	}
 
-	for _, tlv := range mapTLV {
-		tag, err := Raw{}.Deserialize(bytes.NewBuffer(encodeInt(tlv.Tag)))
+		var scratch [4]byte
+		tagRaw, err := Raw{}.AppendSerialize(scratch[:0], field.Name)
 		if err != nil {
-			return nil, DeserializationError{
-				Message: "tag deserializer failed", Serdes: t, Cause: err,
-			}
+			return 0, SerializerError{
+				Message: "tag serializer failed", Serdes: t, Field: field, Cause: err,
+			}.within(field.Name)
 		}
 
-		tagValue, ok := tag.(string)
-		if !ok {
-			return nil, DeserializationError{
-				Message: "tag type is not string", Serdes: t,
-			}
+		tag, _, err := parseTag(tagRaw)
+		if err != nil {
+			return 0, SerializerError{
+				Message: "invalid tag", Serdes: t, Field: field, Value: bytes.NewBuffer(tagRaw), Cause: err,
+			}.within(field.Name)
 		}
 
+		size += len(TagValue{Tag: tag}.tag()) + TagValue{SizeLen: t.SizeLen}.lenSize(valueSize) + valueSize
+	}
+
+	return size, nil
+}
+
+func (t BerTLV) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	listValues := serdes.Map{}
+	if err := t.deserializeInto(data, listValues); err != nil {
+		return nil, err
+	}
+	return listValues, nil
+}
+
+// DeserializeInto clears dst and decodes the listed tags into it.
+func (t BerTLV) DeserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	clearMap(dst)
+	return t.deserializeInto(data, dst)
+}
+
+func (t BerTLV) deserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	mapTLV, offsets, err := decodeOffsets(t.SizeLen, data.Next(data.Len()))
+	if err != nil {
+		return tagsError(t, offsets, err)
+	}
+
+	for index, tlv := range mapTLV {
+		tagValue := hex.EncodeToString(encodeInt(tlv.Tag))
 		field, err := t.findField(tagValue)
 		if err != nil {
 			continue
@@ -123,15 +173,15 @@ func (t BerTLV) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 
 		value, err := field.SerDes.Deserialize(bytes.NewBuffer(tlv.Value))
 		if err != nil {
-			return nil, DeserializationError{
+			return DeserializationError{
 				Message: "struct data deserializer failed", Serdes: t, Field: field, Cause: err,
-			}
+			}.within(tagValue, offsets[index+1]-len(tlv.Value))
 		}
 
-		listValues[tagValue] = value
+		dst[tagValue] = value
 	}
 
-	return listValues, nil
+	return nil
 }
 
 func (t BerTLV) findField(tag string) (Field, error) {
@@ -156,37 +206,52 @@ func (tv TagValue) bytes() []byte {
 
 // tag returns encoded tag value (two bytes tags are supported).
 func (tv TagValue) tag() []byte {
+	return tv.appendTag(nil)
+}
+
+// appendTag appends the encoded tag value.
+func (tv TagValue) appendTag(dst []byte) []byte {
 	if (tv.Tag>>8)&0x1F == 0 {
-		return []byte{byte(tv.Tag)}
+		return append(dst, byte(tv.Tag))
 	}
 
-	return []byte{byte(tv.Tag >> 8), byte(tv.Tag & 0xff)}
+	return append(dst, byte(tv.Tag>>8), byte(tv.Tag&0xff))
 }
 
 // len returns encoded length of the value.
 func (tv TagValue) len() []byte {
-	l := len(tv.Value)
+	return tv.appendLen(nil, len(tv.Value))
+}
 
+// appendLen appends the encoded length l.
+func (tv TagValue) appendLen(dst []byte, l int) []byte {
 	// build size with fixed length
 	if tv.SizeLen > 0 {
-		r := encodeInt(l)
-		result := make([]byte, tv.SizeLen-len(r))
-		return append(result, r...)
+		for size := intSize(l); size < tv.SizeLen; size++ {
+			dst = append(dst, 0)
+		}
+		return appendInt(dst, l)
 	}
 
 	// the first byte is a final byte?
 	if l <= 0x7f {
-		return []byte{byte(l)}
+		return append(dst, byte(l))
 	}
 
-	r := encodeInt(l)
-	numOctets := len(r)
-	result := make([]byte, 1+numOctets)
-	result[0] = 0x80 | byte(numOctets)
+	dst = append(dst, 0x80|byte(intSize(l)))
+	return appendInt(dst, l)
+}
 
-	copy(result[1:], r)
+// lenSize returns the size of the encoded length of a value of l bytes.
+func (tv TagValue) lenSize(l int) int {
+	if tv.SizeLen > 0 {
+		return tv.SizeLen
+	}
 
-	return result
+	if l <= 0x7f {
+		return 1
+	}
+	return 1 + intSize(l)
 }
 
 // readFrom implements io.ReaderFrom.
@@ -229,6 +294,25 @@ func (tv TagValue) isConstructed() bool {
 	return (tv.Tag>>8)&0x20 != 0
 }
 
+// parseTag parses the tag at the start of data like readTag.
+func parseTag(data []byte) (tag int, n int, err error) {
+	if len(data) == 0 {
+		return 0, 0, io.EOF
+	}
+
+	tag = int(data[0])
+
+	// it's a two byte tag
+	if data[0]&0x1F == 0x1F {
+		if len(data) < 2 {
+			return 0, 1, io.EOF
+		}
+		return tag<<8 | int(data[1]), 2, nil
+	}
+
+	return tag, 1, nil
+}
+
 // readTag reads length of a tag and return it along with length of a length in bytes or an error.
 func readTag(r io.Reader) (tag int, n int, err error) {
 	b := make([]byte, 1)
@@ -303,40 +387,54 @@ func readLen(sizeTam int, r io.Reader) (length int, n int, err error) {
 
 // encodeInt encodes an integer to BER format.
 func encodeInt(in int) []byte {
-	result := make([]byte, 4)
+	return appendInt(make([]byte, 0, 4), in)
+}
 
-	binary.BigEndian.PutUint32(result, uint32(in))
+// appendInt appends the integer encoded in BER format.
+func appendInt(dst []byte, in int) []byte {
+	var result [4]byte
+	binary.BigEndian.PutUint32(result[:], uint32(in))
+	return append(dst, result[4-intSize(in):]...)
+}
 
-	var lz int
-	for ; lz < 4; lz++ {
-		if result[lz] != 0 {
-			break
-		}
+// intSize returns the number of bytes of the integer encoded in BER format.
+func intSize(in int) int {
+	size := 4
+	for size > 0 && uint32(in)>>(8*uint(size-1)) == 0 {
+		size--
 	}
-
-	return result[lz:]
+	return size
 }
 
 // decode decodes TLV encoded byte slice into slice of TagValue structs.
 func decode(sizeTam int, p []byte) ([]TagValue, error) {
+	result, _, err := decodeOffsets(sizeTam, p)
+	return result, err
+}
+
+// decodeOffsets decodes TLV encoded byte slice like decode, it also returns the offsets of the tags in p followed by
+// the offset of their end, which is the offset of the tag that could not be decoded on errors.
+func decodeOffsets(sizeTam int, p []byte) ([]TagValue, []int, error) {
 	r := bytes.NewReader(p)
 
-	tv := TagValue{SizeLen: sizeTam}
 	var result []TagValue
+	offsets := []int{0}
 	for {
+		tv := TagValue{SizeLen: sizeTam}
 		_, err := tv.readFrom(r)
 		if err == io.EOF {
 			break
 		}
 
 		if err != nil {
-			return nil, err
+			return nil, offsets, err
 		}
 
 		result = append(result, tv)
+		offsets = append(offsets, len(p)-r.Len())
 	}
 
-	return result, nil
+	return result, offsets, nil
 }
 
 // Find finds first tag (DFS) in the TLV structure represented by p.
@@ -367,3 +465,11 @@ func Find(sizeTam int, tag int, p []byte) (*TagValue, error) {
 
 	return nil, ErrTagNotFound
 }
+
+func (t BerTLV) Children() []Child {
+	return fieldChildren(t.Items)
+}
+
+func (t BerTLV) FixedSize() (int, bool) {
+	return 0, false
+}
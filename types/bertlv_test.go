@@ -330,3 +330,11 @@ func TestFind(t *testing.T) {
 		})
 	}
 }
+
+func TestBerTLV_Deserialize_Consumes_Buffer(t *testing.T) {
+	data := bytes.NewBuffer([]byte{0x9f, 0x36, 0x02, 0x00, 0x01})
+	value, err := types.BerTLV{Items: []types.Field{{Name: "9f36", SerDes: types.Raw{}}}}.Deserialize(data)
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{"9f36": "0001"}, value)
+	assert.Equal(t, 0, data.Len())
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/hex"
 
 	"github.com/mercadolibre/go-iso8583/serdes"
 )
@@ -9,6 +10,7 @@ import (
 type Bitmap struct {
 	BlockSize int
 	NumBits   int
+	Hex       bool // the bitmap is encoded as an hex string, each block takes BlockSize/4 chars.
 }
 
 func (Bitmap) Name() string {
@@ -16,22 +18,58 @@ func (Bitmap) Name() string {
 }
 
 func (bitmap Bitmap) Serialize(value serdes.Value) (*bytes.Buffer, error) {
-	blockSizeInBytes := bitmap.BlockSize / 8
+	return serialize(bitmap, value)
+}
+
+// AppendSerialize appends the bitmap of the []byte value, padded to complete the last block.
+func (bitmap Bitmap) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
 	rawValue, ok := value.([]byte)
 	if !ok {
-		return nil, SerializerError{
+		return dst, SerializerError{
 			Message: "invalid value type", Serdes: bitmap, Value: value,
 		}
 	}
+	return bitmap.appendBits(dst, rawValue), nil
+}
+
+// Size returns the size of the blocks of the bits, two hex characters by byte for hex bitmaps.
+func (bitmap Bitmap) Size(value serdes.Value) (int, error) {
+	rawValue, ok := value.([]byte)
+	if !ok {
+		return 0, SerializerError{
+			Message: "invalid value type", Serdes: bitmap, Value: value,
+		}
+	}
+	return bitmap.size(len(rawValue)), nil
+}
+
+// size returns the encoded size of a bitmap of numBytes bytes.
+func (bitmap Bitmap) size(numBytes int) int {
+	blockSizeInBytes := bitmap.BlockSize / 8
+	size := (numBytes + blockSizeInBytes - 1) / blockSizeInBytes * blockSizeInBytes
+	if bitmap.Hex {
+		size *= 2
+	}
+	return size
+}
+
+func (bitmap Bitmap) appendBits(dst []byte, bits []byte) []byte {
+	start := len(dst)
+	return bitmap.encodeBits(append(dst, bits...), start)
+}
 
-	lenValue := len(rawValue)
+// encodeBits encodes in place the bits of dst[start:], which is extended with the padding and the hex encoding.
+func (bitmap Bitmap) encodeBits(dst []byte, start int) []byte {
+	blockSizeInBytes := bitmap.BlockSize / 8
+	lenValue := len(dst) - start
 	numBlocks := lenValue / blockSizeInBytes
 	padding := blockSizeInBytes - (lenValue % blockSizeInBytes)
 	if padding != blockSizeInBytes {
 		numBlocks++
-		rawValue = append(rawValue, make([]byte, padding)...)
+		dst = grow(dst, padding)
 	}
 
+	rawValue := dst[start:]
 	for blockIndex := 0; blockIndex < numBlocks; blockIndex++ {
 		offset := blockIndex * blockSizeInBytes
 
@@ -41,25 +79,51 @@ func (bitmap Bitmap) Serialize(value serdes.Value) (*bytes.Buffer, error) {
 		}
 	}
 
-	return bytes.NewBuffer(rawValue), nil
+	if !bitmap.Hex {
+		return dst
+	}
+
+	// encodes the bytes in place from the last one, each byte takes two chars.
+	numBytes := len(rawValue)
+	dst = grow(dst, numBytes)
+	encoded := dst[start:]
+	for index := numBytes - 1; index >= 0; index-- {
+		b := encoded[index]
+		encoded[2*index] = upperHex[b>>4]
+		encoded[2*index+1] = upperHex[b&0x0f]
+	}
+	return dst
 }
 
+const upperHex = "0123456789ABCDEF"
+
 func (bitmap Bitmap) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	maxNumBlocks := bitmap.NumBits / bitmap.BlockSize
 	blockSizeInBytes := bitmap.BlockSize / 8
+	encodedBlockSize := blockSizeInBytes
+	if bitmap.Hex {
+		encodedBlockSize *= 2
+	}
 
 	var value []byte
 	moreBlocks := true
 
 	for blockIndex := 0; blockIndex < maxNumBlocks && moreBlocks; blockIndex++ {
-		if data.Len() < blockSizeInBytes {
+		if data.Len() < encodedBlockSize {
+			decoded := blockIndex * encodedBlockSize
 			return nil, DeserializationError{
 				Message: "data has no bytes enough to decode block", Serdes: bitmap, Remaning: data.Len(),
-			}
+			}.shortBuffer(decoded+encodedBlockSize, decoded+data.Len())
 		}
 
 		block := make([]byte, blockSizeInBytes)
-		if _, err := data.Read(block); err != nil {
+		if bitmap.Hex {
+			if _, err := hex.Decode(block, data.Next(encodedBlockSize)); err != nil {
+				return nil, DeserializationError{
+					Message: "error decoding hex block", Serdes: bitmap, Remaning: data.Len(), Cause: err, Err: ErrInvalidCharacter,
+				}
+			}
+		} else if _, err := data.Read(block); err != nil {
 			return nil, DeserializationError{
 				Message: "error reading data", Serdes: bitmap, Remaning: data.Len(),
 			}
@@ -75,3 +139,25 @@ func (bitmap Bitmap) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 
 	return value, nil
 }
+
+// Description returns an empty description, the bitmap is described by its BitMapped.
+func (Bitmap) Description() string {
+	return ""
+}
+
+func (Bitmap) Children() []Child {
+	return nil
+}
+
+// FixedSize returns the size of a bitmap with a single block, the number of blocks of the others depends on the value.
+func (bitmap Bitmap) FixedSize() (int, bool) {
+	if bitmap.BlockSize <= 0 || bitmap.NumBits != bitmap.BlockSize {
+		return 0, false
+	}
+
+	size := bitmap.BlockSize / 8
+	if bitmap.Hex {
+		size *= 2
+	}
+	return size, true
+}
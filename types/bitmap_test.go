@@ -53,3 +53,24 @@ func Test_Bitmap_Deserialize_Error(t *testing.T) {
 	_, err := des.Deserialize(data)
 	assert.Error(t, err)
 }
+
+func Test_Bitmap_Hex(t *testing.T) {
+	ser := types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true}
+
+	value128 := []byte{0x72, 0x34, 0x05, 0x41, 0x28, 0xC2, 0x88, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}
+	data, err := ser.Serialize(value128)
+	assert.NoError(t, err)
+	assert.Equal(t, "F234054128C288050000000000000001", data.String())
+
+	data.WriteString("NEXT")
+	value, err := ser.Deserialize(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x72, 0x34, 0x05, 0x41, 0x28, 0xC2, 0x88, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}, value)
+	assert.Equal(t, "NEXT", data.String())
+
+	_, err = ser.Deserialize(bytes.NewBufferString("7234054128C28805"[:10]))
+	assert.Error(t, err)
+
+	_, err = ser.Deserialize(bytes.NewBufferString("7234054128C2880Z"))
+	assert.Error(t, err)
+}
@@ -20,42 +20,108 @@ func (bitMapped BitMapped) Name() string {
 }
 
 func (bitMapped BitMapped) Serialize(value serdes.Value) (*bytes.Buffer, error) {
-	bitsValue, sortedBitsNumber, err := bitMapped.normalizeValue(value)
+	return serialize(bitMapped, value)
+}
+
+// AppendSerialize appends the bitmap of the numeric keys of the value followed by their fields in bit order.
+func (bitMapped BitMapped) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	return bitMapped.appendWith(dst, value, nil)
+}
+
+// appendWith encodes the value like AppendSerialize, the fields are encoded by the serdes of their bit number when
+// they are given, which encode the values of the serdes of the Mapping.
+func (bitMapped BitMapped) appendWith(dst []byte, value serdes.Value, fields []serdes.Serdes) ([]byte, error) {
+	var scratch [64]bitValue
+	bitsValue, err := bitMapped.normalizeValue(scratch[:0], value)
 	if err != nil {
-		return nil, err
+		return dst, err
 	}
 
-	numBits := len(sortedBitsNumber)
-	if numBits == 0 {
-		return &bytes.Buffer{}, nil
+	if len(bitsValue) == 0 {
+		return dst, nil
 	}
 
-	bitmap, err := bitMapped.serializeBitmap(sortedBitsNumber, numBits)
+	start := len(dst)
+	dst = bitMapped.appendBitmap(dst, bitsValue)
+	encoded, err := bitMapped.appendFields(dst, bitsValue, fields)
 	if err != nil {
-		return nil, err
+		return dst[:start], err
 	}
+	return encoded, nil
+}
 
-	fields, err := bitMapped.serializeFields(sortedBitsNumber, bitsValue)
+// Size returns the size of the bitmap of the numeric keys of the value and of their fields.
+func (bitMapped BitMapped) Size(value serdes.Value) (int, error) {
+	var scratch [64]bitValue
+	bitsValue, err := bitMapped.normalizeValue(scratch[:0], value)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+
+	if len(bitsValue) == 0 {
+		return 0, nil
 	}
 
-	data := append(bitmap.Bytes(), fields.Bytes()...)
-	return bytes.NewBuffer(data), nil
+	lastBitNumber := bitsValue[len(bitsValue)-1].bitNumber
+	size := bitMapped.Bitmap.size((lastBitNumber + 7) / 8)
+	for _, bit := range bitsValue {
+		serializer, exists := bitMapped.Mapping[bit.bitNumber]
+		if !exists || serializer == nil {
+			return 0, SerializerError{
+				Message: fmt.Sprintf("bit number %d not found", bit.bitNumber), Serdes: bitMapped, Value: maskValue(serializer, bit.value),
+				Err: ErrUnknownBit,
+			}.within(strconv.Itoa(bit.bitNumber))
+		}
+
+		fieldSize, err := serdes.Size(serializer, bit.value)
+		if err != nil {
+			return 0, SerializerError{
+				Message: "serializer failed", Serdes: bitMapped, Field: Field{Name: strconv.Itoa(bit.bitNumber), SerDes: serializer}, Value: maskValue(serializer, bit.value), Cause: err,
+			}.within(strconv.Itoa(bit.bitNumber))
+		}
+		size += fieldSize
+	}
+
+	return size, nil
 }
 
 func (bitMapped BitMapped) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	return bitMapped.deserializeWith(data, nil)
+}
+
+// deserializeWith decodes the value like Deserialize, see deserializeIntoWith.
+func (bitMapped BitMapped) deserializeWith(data *bytes.Buffer, fields []serdes.Serdes) (serdes.Value, error) {
 	values := make(serdes.Map)
+	if err := bitMapped.deserializeIntoWith(data, values, fields); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// DeserializeInto clears dst and decodes the fields of the bitmap into it.
+func (bitMapped BitMapped) DeserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	clearMap(dst)
+	return bitMapped.deserializeInto(data, dst)
+}
+
+func (bitMapped BitMapped) deserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	return bitMapped.deserializeIntoWith(data, dst, nil)
+}
+
+// deserializeIntoWith decodes the fields like deserializeInto, the fields are decoded by the serdes of their bit number
+// when they are given.
+func (bitMapped BitMapped) deserializeIntoWith(data *bytes.Buffer, dst serdes.Map, fields []serdes.Serdes) error {
+	size := data.Len()
 	bitmapValue, err := bitMapped.Bitmap.Deserialize(data)
 	if err != nil {
-		return nil, DeserializationError{
+		return DeserializationError{
 			Message: "error decoding bitmap", Serdes: bitMapped, Remaning: data.Len(), Cause: err,
-		}
+		}.within("", 0)
 	}
 
 	bitmap, ok := bitmapValue.([]byte)
 	if !ok {
-		return nil, DeserializationError{
+		return DeserializationError{
 			Message: "bitmap was deserialized to an invalid type", Serdes: bitMapped, Remaning: data.Len(), Cause: err,
 		}
 	}
@@ -67,104 +133,145 @@ func (bitMapped BitMapped) Deserialize(data *bytes.Buffer) (serdes.Value, error)
 			continue
 		}
 
-		deserializer, exists := bitMapped.Mapping[bitNumber]
-		if !exists || deserializer == nil {
-			return nil, DeserializationError{
+		bitKey := strconv.Itoa(bitNumber)
+		offset := size - data.Len()
+		deserializer := bitMapped.field(bitNumber, fields)
+		if deserializer == nil {
+			return DeserializationError{
 				Message: fmt.Sprintf("bit %d not found", bitNumber), Serdes: bitMapped, Remaning: data.Len(), Cause: err,
-			}
+				Err: ErrUnknownBit,
+			}.within(bitKey, offset)
 		}
 
 		value, err := deserializer.Deserialize(data)
 		if err != nil {
-			return nil, DeserializationError{
-				Message: fmt.Sprintf("deserialize bit %d failed", bitNumber), Serdes: bitMapped, Remaning: data.Len(), Cause: err,
-			}
+			return DeserializationError{
+				Message: fmt.Sprintf("deserialize bit %d failed", bitNumber), Serdes: bitMapped,
+				Field: Field{Name: bitKey, SerDes: bitMapped.Mapping[bitNumber]}, Remaning: data.Len(), Cause: err,
+			}.within(bitKey, offset)
 		}
 
-		bitKey := strconv.Itoa(bitNumber)
-		values[bitKey] = value
+		dst[bitKey] = value
 	}
 
-	return values, nil
+	return nil
+}
+
+// bitValue is the value of a field of the bitmap.
+type bitValue struct {
+	bitNumber int
+	value     serdes.Value
 }
 
-func (bitMapped BitMapped) normalizeValue(value serdes.Value) (map[int]interface{}, []int, error) {
+// normalizeValue appends to bitsValue the values of the numeric keys sorted by bit number.
+func (bitMapped BitMapped) normalizeValue(bitsValue []bitValue, value serdes.Value) ([]bitValue, error) {
 	mapStringValue, ok := value.(serdes.Map)
 	if !ok {
-		return nil, nil, SerializerError{
-			Message: "invalid value", Value: value, Serdes: bitMapped,
+		return nil, SerializerError{
+			Message: "invalid value", Value: maskValue(bitMapped, value), Serdes: bitMapped,
 		}
 	}
 
-	var index int
-	var list []int
-	mapIntValue := map[int]interface{}{}
-
 	for key, value := range mapStringValue {
-		bitNumber, err := strconv.Atoi(key)
-		if err != nil {
+		bitNumber, ok := parseBit(key)
+		if !ok {
 			continue
 		}
 
-		mapIntValue[bitNumber] = value
-		list = append(list, bitNumber)
-		index++
+		bitsValue = append(bitsValue, bitValue{bitNumber: bitNumber, value: value})
 	}
 
-	sort.Ints(list)
-	return mapIntValue, list, nil
+	// insertion sort, the fields of a message are few and sort.Slice allocates.
+	for i := 1; i < len(bitsValue); i++ {
+		for j := i; j > 0 && bitsValue[j].bitNumber < bitsValue[j-1].bitNumber; j-- {
+			bitsValue[j], bitsValue[j-1] = bitsValue[j-1], bitsValue[j]
+		}
+	}
+	return bitsValue, nil
 }
 
-func (bitMapped BitMapped) serializeBitmap(sortedBitsNumber []int, numBits int) (*bytes.Buffer, error) {
-	lastBitNumber := sortedBitsNumber[numBits-1]
+// parseBit returns the bit number of the key like strconv.Atoi, the keys that don't start like a number, like the
+// MTI of the message map, are skipped without parsing them.
+func parseBit(key string) (int, bool) {
+	if key == "" {
+		return 0, false
+	}
+
+	if c := key[0]; (c < '0' || c > '9') && c != '+' && c != '-' {
+		return 0, false
+	}
+
+	if len(key) < 10 {
+		bitNumber := 0
+		for i := 0; i < len(key); i++ {
+			c := key[i]
+			if c < '0' || c > '9' {
+				bitNumber = -1
+				break
+			}
+			bitNumber = bitNumber*10 + int(c-'0')
+		}
+
+		if bitNumber >= 0 {
+			return bitNumber, true
+		}
+	}
+
+	bitNumber, err := strconv.Atoi(key)
+	return bitNumber, err == nil
+}
+
+func (bitMapped BitMapped) appendBitmap(dst []byte, bitsValue []bitValue) []byte {
+	lastBitNumber := bitsValue[len(bitsValue)-1].bitNumber
 	nbytes := lastBitNumber / 8
 	if lastBitNumber%8 > 0 {
 		nbytes++
 	}
 
-	bitmap := make([]byte, nbytes)
-	for _, bitNumber := range sortedBitsNumber {
-		bitIndex := bitNumber - 1
+	start := len(dst)
+	dst = grow(dst, nbytes)
+	bitmap := dst[start:]
+	for _, bit := range bitsValue {
+		bitIndex := bit.bitNumber - 1
 		cbit := 7 - uint(bitIndex%8)
 		bitmap[bitIndex/8] |= 0x1 << cbit
 	}
 
-	data, err := bitMapped.Bitmap.Serialize(bitmap)
-	if err != nil {
-		return nil, SerializerError{
-			Message: "error serializing bitmap", Serdes: bitMapped, Cause: err,
-		}
-	}
-
-	return data, nil
+	return bitMapped.Bitmap.encodeBits(dst, start)
 }
 
-func (bitMapped BitMapped) serializeFields(sortedBitsNumber []int, bitsValue map[int]interface{}) (*bytes.Buffer, error) {
-	out := new(bytes.Buffer)
-	for _, bitNumber := range sortedBitsNumber {
-		serializer, exists := bitMapped.Mapping[bitNumber]
-		if !exists || serializer == nil {
-			return nil, SerializerError{
-				Message: fmt.Sprintf("bit number %d not found", bitNumber), Serdes: bitMapped, Value: bitsValue,
-			}
+func (bitMapped BitMapped) appendFields(dst []byte, bitsValue []bitValue, fields []serdes.Serdes) ([]byte, error) {
+	for _, bit := range bitsValue {
+		serializer := bitMapped.field(bit.bitNumber, fields)
+		if serializer == nil {
+			return dst, SerializerError{
+				Message: fmt.Sprintf("bit number %d not found", bit.bitNumber), Serdes: bitMapped, Value: maskValue(serializer, bit.value),
+				Err: ErrUnknownBit,
+			}.within(strconv.Itoa(bit.bitNumber))
 		}
 
-		value := bitsValue[bitNumber]
-		bitData, err := serializer.Serialize(value)
+		encoded, err := serdes.AppendSerialize(dst, serializer, bit.value)
 		if err != nil {
-			return nil, SerializerError{
-				Message: "serializer failed", Serdes: bitMapped, Field: Field{Name: strconv.Itoa(bitNumber), SerDes: serializer}, Value: value, Cause: err,
-			}
+			fieldSerdes := bitMapped.Mapping[bit.bitNumber]
+			return dst, SerializerError{
+				Message: "serializer failed", Serdes: bitMapped, Field: Field{Name: strconv.Itoa(bit.bitNumber), SerDes: fieldSerdes}, Value: maskValue(fieldSerdes, bit.value), Cause: err,
+			}.within(strconv.Itoa(bit.bitNumber))
 		}
+		dst = encoded
+	}
 
-		if _, err := out.ReadFrom(bitData); err != nil {
-			return nil, SerializerError{
-				Message: "error coping buffer", Serdes: bitMapped, Field: Field{Name: strconv.Itoa(bitNumber), SerDes: serializer}, Value: value, Cause: err,
-			}
+	return dst, nil
+}
+
+// field returns the serdes of the bit number, from the fields when they are given, nil when the bit has no field.
+func (bitMapped BitMapped) field(bitNumber int, fields []serdes.Serdes) serdes.Serdes {
+	if fields != nil {
+		if bitNumber < len(fields) {
+			return fields[bitNumber]
 		}
+		return nil
 	}
-
-	return out, nil
+	return bitMapped.Mapping[bitNumber]
 }
 
 func (bitMapped *BitMapped) checkBit(bitmap []byte, index int) bool {
@@ -174,3 +281,23 @@ func (bitMapped *BitMapped) checkBit(bitmap []byte, index int) bool {
 	status := bitmap[cbyte]&(0x1<<uint(cbit)) != 0
 	return status
 }
+
+// Children returns the bitmap followed by the fields sorted by bit number.
+func (bitMapped BitMapped) Children() []Child {
+	bits := make([]int, 0, len(bitMapped.Mapping))
+	for bit := range bitMapped.Mapping {
+		bits = append(bits, bit)
+	}
+	sort.Ints(bits)
+
+	children := make([]Child, 0, len(bits)+1)
+	children = append(children, Child{Kind: BitmapChild, SerDes: bitMapped.Bitmap})
+	for _, bit := range bits {
+		children = append(children, Child{Key: strconv.Itoa(bit), Kind: FieldChild, SerDes: bitMapped.Mapping[bit]})
+	}
+	return children
+}
+
+func (bitMapped BitMapped) FixedSize() (int, bool) {
+	return 0, false
+}
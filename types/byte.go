@@ -9,6 +9,7 @@ import (
 
 type Byte struct {
 	Desc
+	Mask
 }
 
 func (b Byte) Name() string {
@@ -16,27 +17,37 @@ func (b Byte) Name() string {
 }
 
 func (b Byte) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(b, value)
+}
+
+func (b Byte) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
 	valueInt, err := b.valueAsInt(value)
 	if err != nil {
-		return nil, err
+		return dst, err
 	}
 
-	data := []byte{byte(valueInt)}
-	return bytes.NewBuffer(data), nil
+	return append(dst, byte(valueInt)), nil
+}
+
+func (b Byte) Size(value serdes.Value) (int, error) {
+	if _, err := b.valueAsInt(value); err != nil {
+		return 0, err
+	}
+	return 1, nil
 }
 
 func (b Byte) valueAsInt(value serdes.Value) (int, error) {
 	valueStr, ok := value.(string)
 	if !ok {
 		return 0, SerializerError{
-			Message: "invalid value", Value: value, Serdes: b,
+			Message: "invalid value", Value: b.MaskValue(value), Serdes: b,
 		}
 	}
 
 	valueInt, err := strconv.Atoi(valueStr)
 	if err != nil {
 		return 0, SerializerError{
-			Message: "invalid data", Serdes: b, Value: value, Cause: err,
+			Message: "invalid data", Serdes: b, Value: b.MaskValue(value), Cause: numberCause(err),
 		}
 	}
 	return valueInt, nil
@@ -46,7 +57,7 @@ func (b Byte) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	if data.Len() == 0 {
 		return nil, DeserializationError{
 			Message: "does not has data enough", Serdes: b, Remaning: data.Len(),
-		}
+		}.shortBuffer(1, data.Len())
 	}
 
 	deserializedByte, err := data.ReadByte()
@@ -59,3 +70,11 @@ func (b Byte) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	value := strconv.Itoa(int(deserializedByte))
 	return value, nil
 }
+
+func (b Byte) Children() []Child {
+	return nil
+}
+
+func (b Byte) FixedSize() (int, bool) {
+	return 1, true
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/mercadolibre/go-iso8583/serdes"
 )
 
 type Ebcdic struct {
 	Desc
+	Mask
 	NumDigits int
 }
 
@@ -57,10 +59,15 @@ func (ebcdic Ebcdic) Name() string {
 }
 
 func (ebcdic Ebcdic) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(ebcdic, value)
+}
+
+// AppendSerialize appends the value left justified and padded with spaces.
+func (ebcdic Ebcdic) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
 	valueStr, ok := value.(string)
 	if !ok {
-		return nil, SerializerError{
-			Message: "invalid value type", Serdes: ebcdic, Value: value,
+		return dst, SerializerError{
+			Message: "invalid value type", Serdes: ebcdic, Value: ebcdic.MaskValue(value),
 		}
 	}
 
@@ -71,18 +78,50 @@ func (ebcdic Ebcdic) Serialize(value serdes.Value) (*bytes.Buffer, error) {
 	}
 
 	if ebcdic.NumDigits > 0 && valueLen > numDigits {
-		return nil, SerializerError{
-			Message: "value too long", Serdes: ebcdic, Value: value,
+		return dst, SerializerError{
+			Message: "value too long", Serdes: ebcdic, Value: ebcdic.MaskValue(value), Err: ErrValueTooLong,
 		}
 	}
 
-	paddedValue := fmt.Sprintf("%-*s", numDigits, valueStr)
-	data := new(bytes.Buffer)
-	for _, c := range paddedValue {
-		data.WriteByte(asciiToEbcdic[c])
+	dst, err := appendEbcdic(dst, valueStr)
+	if err != nil {
+		return dst, SerializerError{
+			Message: "invalid value", Serdes: ebcdic, Value: ebcdic.MaskValue(value), Cause: err, Err: ErrInvalidCharacter,
+		}
 	}
 
-	return data, nil
+	for count := utf8.RuneCountInString(valueStr); count < numDigits; count++ {
+		dst = append(dst, asciiToEbcdic[' '])
+	}
+	return dst, nil
+}
+
+// Size returns the number of characters of the value padded to NumDigits.
+func (ebcdic Ebcdic) Size(value serdes.Value) (int, error) {
+	valueStr, ok := value.(string)
+	if !ok {
+		return 0, SerializerError{
+			Message: "invalid value type", Serdes: ebcdic, Value: ebcdic.MaskValue(value),
+		}
+	}
+
+	if ebcdic.NumDigits > 0 && len(valueStr) > ebcdic.NumDigits {
+		return 0, SerializerError{
+			Message: "value too long", Serdes: ebcdic, Value: ebcdic.MaskValue(value), Err: ErrValueTooLong,
+		}
+	}
+
+	size, err := ebcdicSize(valueStr)
+	if err != nil {
+		return 0, SerializerError{
+			Message: "invalid value", Serdes: ebcdic, Value: ebcdic.MaskValue(value), Cause: err, Err: ErrInvalidCharacter,
+		}
+	}
+	numDigits := ebcdic.NumDigits
+	if numDigits == 0 {
+		numDigits = len(valueStr)
+	}
+	return maxInt(size, numDigits), nil
 }
 
 func (ebcdic Ebcdic) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
@@ -94,21 +133,58 @@ func (ebcdic Ebcdic) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	if data.Len() < numDigits {
 		return nil, DeserializationError{
 			Message: "data does not has bytes enough", Serdes: ebcdic, Remaning: data.Len(),
+		}.shortBuffer(numDigits, data.Len())
+	}
+
+	out := decodeEbcdic(data.Next(numDigits))
+	return strings.TrimRight(out, " "), nil
+}
+
+// appendEbcdic appends the characters of the value encoded in EBCDIC, dst is returned unchanged on error.
+func appendEbcdic(dst []byte, value string) ([]byte, error) {
+	start := len(dst)
+	for _, c := range value {
+		if int(c) >= len(asciiToEbcdic) {
+			return dst[:start], fmt.Errorf("character %q has no EBCDIC encoding", c)
 		}
+		dst = append(dst, asciiToEbcdic[c])
 	}
+	return dst, nil
+}
 
-	var out string
-	for count := 0; count < numDigits; count++ {
-		c, err := data.ReadByte()
-		if err != nil {
-			return nil, DeserializationError{
-				Message: "error reading bytes", Serdes: ebcdic, Remaning: data.Len(),
-			}
+// ebcdicSize returns the number of EBCDIC characters of the value.
+func ebcdicSize(value string) (int, error) {
+	size := 0
+	for _, c := range value {
+		if int(c) >= len(asciiToEbcdic) {
+			return 0, fmt.Errorf("character %q has no EBCDIC encoding", c)
+		}
+		size++
+	}
+	return size, nil
+}
+
+// decodeEbcdic returns the EBCDIC encoded characters as a string.
+func decodeEbcdic(data []byte) string {
+	out := make([]byte, 0, len(data))
+	for _, c := range data {
+		r := ebcdicToASCII[c]
+		if r < utf8.RuneSelf {
+			out = append(out, r)
+			continue
 		}
 
-		out += string(ebcdicToASCII[c])
+		var encoded [utf8.UTFMax]byte
+		size := utf8.EncodeRune(encoded[:], rune(r))
+		out = append(out, encoded[:size]...)
 	}
+	return string(out)
+}
+
+func (ebcdic Ebcdic) Children() []Child {
+	return nil
+}
 
-	out = strings.TrimRight(out, " ")
-	return out, nil
+func (ebcdic Ebcdic) FixedSize() (int, bool) {
+	return fixedDigits(ebcdic.NumDigits)
 }
@@ -2,13 +2,14 @@ package types
 
 import (
 	"bytes"
-	"fmt"
+	"unicode/utf8"
 
 	"github.com/mercadolibre/go-iso8583/serdes"
 )
 
 type EbcdicNumeric struct {
 	Desc
+	Mask
 	NumDigits int
 }
 
@@ -17,13 +18,22 @@ func (ebcdic EbcdicNumeric) Name() string {
 }
 
 func (ebcdic EbcdicNumeric) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(ebcdic, value)
+}
+
+// AppendSerialize appends the value right justified and padded with zeros.
+func (ebcdic EbcdicNumeric) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
 	valueStr, ok := value.(string)
 	if !ok {
-		return nil, SerializerError{
-			Message: "invalid value type", Serdes: ebcdic, Value: value,
+		return dst, SerializerError{
+			Message: "invalid value type", Serdes: ebcdic, Value: ebcdic.MaskValue(value),
 		}
 	}
+	return ebcdic.appendString(dst, valueStr)
+}
 
+// appendString appends the string like AppendSerialize, without converting it to a serdes.Value.
+func (ebcdic EbcdicNumeric) appendString(dst []byte, valueStr string) ([]byte, error) {
 	valueLen := len(valueStr)
 	numDigits := ebcdic.NumDigits
 	if numDigits == 0 {
@@ -31,18 +41,51 @@ func (ebcdic EbcdicNumeric) Serialize(value serdes.Value) (*bytes.Buffer, error)
 	}
 
 	if ebcdic.NumDigits > 0 && valueLen > numDigits {
-		return nil, SerializerError{
-			Message: "value too long", Serdes: ebcdic, Value: value,
+		return dst, SerializerError{
+			Message: "value too long", Serdes: ebcdic, Value: ebcdic.MaskValue(valueStr), Err: ErrValueTooLong,
 		}
 	}
 
-	paddedValue := fmt.Sprintf("%0*s", numDigits, valueStr)
-	data := new(bytes.Buffer)
-	for _, c := range paddedValue {
-		data.WriteByte(asciiToEbcdic[c])
+	start := len(dst)
+	for count := utf8.RuneCountInString(valueStr); count < numDigits; count++ {
+		dst = append(dst, asciiToEbcdic['0'])
 	}
 
-	return data, nil
+	dst, err := appendEbcdic(dst, valueStr)
+	if err != nil {
+		return dst[:start], SerializerError{
+			Message: "invalid value", Serdes: ebcdic, Value: ebcdic.MaskValue(valueStr), Cause: err, Err: ErrInvalidCharacter,
+		}
+	}
+	return dst, nil
+}
+
+// Size returns the number of characters of the value padded to NumDigits.
+func (ebcdic EbcdicNumeric) Size(value serdes.Value) (int, error) {
+	valueStr, ok := value.(string)
+	if !ok {
+		return 0, SerializerError{
+			Message: "invalid value type", Serdes: ebcdic, Value: ebcdic.MaskValue(value),
+		}
+	}
+
+	if ebcdic.NumDigits > 0 && len(valueStr) > ebcdic.NumDigits {
+		return 0, SerializerError{
+			Message: "value too long", Serdes: ebcdic, Value: ebcdic.MaskValue(value), Err: ErrValueTooLong,
+		}
+	}
+
+	size, err := ebcdicSize(valueStr)
+	if err != nil {
+		return 0, SerializerError{
+			Message: "invalid value", Serdes: ebcdic, Value: ebcdic.MaskValue(value), Cause: err, Err: ErrInvalidCharacter,
+		}
+	}
+	numDigits := ebcdic.NumDigits
+	if numDigits == 0 {
+		numDigits = len(valueStr)
+	}
+	return maxInt(size, numDigits), nil
 }
 
 func (ebcdic EbcdicNumeric) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
@@ -54,20 +97,16 @@ func (ebcdic EbcdicNumeric) Deserialize(data *bytes.Buffer) (serdes.Value, error
 	if data.Len() < numDigits {
 		return nil, DeserializationError{
 			Message: "data does not has bytes enough", Serdes: ebcdic, Remaning: data.Len(),
-		}
+		}.shortBuffer(numDigits, data.Len())
 	}
 
-	var out string
-	for count := 0; count < numDigits; count++ {
-		c, err := data.ReadByte()
-		if err != nil {
-			return nil, DeserializationError{
-				Message: "error reading bytes", Serdes: ebcdic, Remaning: data.Len(),
-			}
-		}
+	return decodeEbcdic(data.Next(numDigits)), nil
+}
 
-		out += string(ebcdicToASCII[c])
-	}
+func (ebcdic EbcdicNumeric) Children() []Child {
+	return nil
+}
 
-	return out, nil
+func (ebcdic EbcdicNumeric) FixedSize() (int, bool) {
+	return fixedDigits(ebcdic.NumDigits)
 }
@@ -1,17 +1,35 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
 
 	"github.com/mercadolibre/go-iso8583/serdes"
 )
 
+var (
+	// ErrShortBuffer is the error of the fields that need more bytes than the data has.
+	ErrShortBuffer = errors.New("short buffer")
+	// ErrInvalidCharacter is the error of the values with characters that the serdes cannot encode or decode.
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrUnknownBit is the error of the bits that are not in the mapping of a BitMapped.
+	ErrUnknownBit = errors.New("unknown bit")
+	// ErrValueTooLong is the error of the values longer than the size of the field.
+	ErrValueTooLong = errors.New("value too long")
+)
+
 type SerializerError struct {
 	Message string       `json:"message"`
 	Serdes  serdes.Named `json:"serdes"`
 	Field   Field        `json:"field"`
 	Value   serdes.Value `json:"value"`
 	Cause   error        `json:"cause"`
+	// Path is the path of the value of the field that failed, relative to the value passed to the serdes.
+	Path string `json:"path,omitempty"`
+	// Err is the sentinel of the error, errors.Is matches it.
+	Err error `json:"-"`
 }
 
 func (err SerializerError) Error() string {
@@ -53,12 +71,36 @@ func (err SerializerError) Unwrap() error {
 	return err.Cause
 }
 
+func (err SerializerError) Is(target error) bool {
+	return err.Err != nil && err.Err == target
+}
+
+// within returns the error of the field key of a composite serdes with the path of the field that failed in the cause.
+func (err SerializerError) within(key string) SerializerError {
+	err.Path = key
+	var cause SerializerError
+	if errors.As(err.Cause, &cause) {
+		err.Path = serdes.JoinPath(key, cause.Path)
+		err.Err = cause.Err
+	}
+	return err
+}
+
 type DeserializationError struct {
 	Message  string       `json:"message"`
 	Serdes   serdes.Named `json:"serdes"`
 	Field    Field        `json:"field"`
 	Remaning int          `json:"remaning"`
 	Cause    error        `json:"cause"`
+	// Path is the path of the value of the field that failed, relative to the serdes.
+	Path string `json:"path,omitempty"`
+	// Offset is the position of the first byte of the field that failed, relative to the data passed to the serdes.
+	Offset int `json:"offset"`
+	// Expected and Actual are the number of bytes that the field needed and the number of bytes that it had.
+	Expected int `json:"expected,omitempty"`
+	Actual   int `json:"actual,omitempty"`
+	// Err is the sentinel of the error, errors.Is matches it.
+	Err error `json:"-"`
 }
 
 func (err DeserializationError) Error() string {
@@ -99,3 +141,46 @@ func (err DeserializationError) Error() string {
 func (err DeserializationError) Unwrap() error {
 	return err.Cause
 }
+
+func (err DeserializationError) Is(target error) bool {
+	return err.Err != nil && err.Err == target
+}
+
+// within returns the error of the field key of a composite serdes, the field starts at offset, with the path and the
+// offset of the field that failed in the cause.
+func (err DeserializationError) within(key string, offset int) DeserializationError {
+	err.Path, err.Offset = key, offset
+	var cause DeserializationError
+	if errors.As(err.Cause, &cause) {
+		err.Path = serdes.JoinPath(key, cause.Path)
+		err.Offset += cause.Offset
+		err.Expected, err.Actual, err.Err = cause.Expected, cause.Actual, cause.Err
+	}
+	return err
+}
+
+// shortBuffer returns the error with the number of bytes that the field needed and the number of bytes that it had.
+func (err DeserializationError) shortBuffer(expected, actual int) DeserializationError {
+	err.Expected, err.Actual, err.Err = expected, actual, ErrShortBuffer
+	return err
+}
+
+// numberCause returns the cause of the error parsing a number without the parsed value, that can be sensitive.
+func numberCause(err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return fmt.Errorf("%s: %w", numErr.Func, numErr.Err)
+	}
+	return err
+}
+
+// tagsError returns the error decoding the tags of a TLV or BerTLV, the tag that failed starts at the last offset.
+func tagsError(s serdes.Named, offsets []int, err error) DeserializationError {
+	tagsErr := DeserializationError{
+		Message: "data struct deserializer failed", Serdes: s, Offset: offsets[len(offsets)-1], Cause: err,
+	}
+	if err == io.ErrUnexpectedEOF {
+		tagsErr.Err = ErrShortBuffer
+	}
+	return tagsErr
+}
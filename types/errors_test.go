@@ -1,8 +1,12 @@
 package types
 
 import (
+	"bytes"
 	"errors"
+	"reflect"
 	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
 )
 
 func TestDeserializationError_Error(t *testing.T) {
@@ -133,3 +137,159 @@ func TestSerializationError_Error(t *testing.T) {
 		})
 	}
 }
+
+func errorsSpec() List {
+	return List{Items: []Field{
+		{Name: "mti", SerDes: AsciiNumeric{NumDigits: 4}},
+		{SerDes: BitMapped{
+			Bitmap: Bitmap{BlockSize: 64, NumBits: 128},
+			Mapping: map[int]serdes.Serdes{
+				2: VarLength{Length: Byte{}, Data: Bcd{}},
+				3: AsciiNumeric{NumDigits: 6},
+				48: VarLength{Length: AsciiNumeric{NumDigits: 3}, Data: List{Items: []Field{
+					{Name: "tcc", SerDes: Ebcdic{NumDigits: 1}},
+					{Name: "se", SerDes: TLV{Items: []Field{
+						{Name: "21", SerDes: EbcdicNumeric{NumDigits: 2}},
+					}}},
+				}}},
+				55: VarLength{Length: Byte{}, Data: BerTLV{Items: []Field{
+					{Name: "9f26", SerDes: Raw{NumBytes: 8}},
+				}}},
+			},
+		}},
+	}}
+}
+
+func message(bitmap []byte, fields ...byte) []byte {
+	data := append([]byte("0100"), bitmap...)
+	return append(data, fields...)
+}
+
+func TestDeserializationError_Path(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		path     string
+		offset   int
+		expected int
+		actual   int
+		sentinel error
+	}{
+		{
+			name:     "short field",
+			data:     message([]byte{0x20, 0, 0, 0, 0, 0, 0, 0}, '0', '0', '0'),
+			path:     "3",
+			offset:   12,
+			expected: 6,
+			actual:   3,
+			sentinel: ErrShortBuffer,
+		},
+		{
+			name:     "short var length data",
+			data:     message([]byte{0x40, 0, 0, 0, 0, 0, 0, 0}, 0x10, 0x47, 0x61),
+			path:     "2",
+			offset:   13,
+			expected: 8,
+			actual:   2,
+			sentinel: ErrShortBuffer,
+		},
+		{
+			name:     "unknown bit",
+			data:     message([]byte{0x10, 0, 0, 0, 0, 0, 0, 0}, '0', '0', '0', '0', '0', '0'),
+			path:     "4",
+			offset:   12,
+			sentinel: ErrUnknownBit,
+		},
+		{
+			name:     "short tlv tag",
+			data:     message([]byte{0, 0, 0, 0, 0, 0x01, 0, 0}, '0', '0', '6', 0xd9, 0xf2, 0xf1, 0xf0, 0xf1, 0xf1),
+			path:     "48.se.21",
+			offset:   20,
+			expected: 2,
+			actual:   1,
+			sentinel: ErrShortBuffer,
+		},
+		{
+			name:     "short bertlv tag",
+			data:     message([]byte{0, 0, 0, 0, 0, 0, 0x02, 0}, 0x05, 0x9f, 0x26, 0x02, 0x01, 0x02),
+			path:     "55.9f26",
+			offset:   16,
+			expected: 8,
+			actual:   2,
+			sentinel: ErrShortBuffer,
+		},
+		{
+			name:     "truncated bertlv",
+			data:     message([]byte{0, 0, 0, 0, 0, 0, 0x02, 0}, 0x03, 0x9f, 0x26, 0x05),
+			path:     "55",
+			offset:   13,
+			sentinel: ErrShortBuffer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := errorsSpec().Deserialize(bytes.NewBuffer(tt.data))
+			if !errors.Is(err, tt.sentinel) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, tt.sentinel)
+			}
+
+			var got DeserializationError
+			if !errors.As(err, &got) {
+				t.Fatalf("errors.As(%v) = false", err)
+			}
+			if got.Path != tt.path || got.Offset != tt.offset || got.Expected != tt.expected || got.Actual != tt.actual {
+				t.Errorf("DeserializationError = %s at %d (%d/%d), want %s at %d (%d/%d)",
+					got.Path, got.Offset, got.Expected, got.Actual, tt.path, tt.offset, tt.expected, tt.actual)
+			}
+
+			plan, err := Compile(errorsSpec())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, planErr := plan.Deserialize(bytes.NewBuffer(tt.data)); !reflect.DeepEqual(planErr, got) {
+				t.Errorf("Plan.Deserialize() error = %#v, want %#v", planErr, got)
+			}
+		})
+	}
+}
+
+func TestSerializationError_Path(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    serdes.Map
+		path     string
+		sentinel error
+	}{
+		{
+			name:     "value too long",
+			value:    serdes.Map{"mti": "0100", "48": serdes.Map{"tcc": "R", "se": serdes.Map{"21": "123"}}},
+			path:     "48.se.21",
+			sentinel: ErrValueTooLong,
+		},
+		{
+			name:     "invalid character",
+			value:    serdes.Map{"mti": "0100", "2": "47617x"},
+			path:     "2",
+			sentinel: ErrInvalidCharacter,
+		},
+		{
+			name:     "unknown bit",
+			value:    serdes.Map{"mti": "0100", "4": "000000001000"},
+			path:     "4",
+			sentinel: ErrUnknownBit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := errorsSpec().Serialize(tt.value)
+			if !errors.Is(err, tt.sentinel) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, tt.sentinel)
+			}
+
+			var got SerializerError
+			if !errors.As(err, &got) || got.Path != tt.path {
+				t.Errorf("SerializerError.Path = %s, want %s", got.Path, tt.path)
+			}
+		})
+	}
+}
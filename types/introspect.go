@@ -0,0 +1,105 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+)
+
+// SkipChildren can be returned by a VisitFunc to avoid walking into the children of the serdes being visited.
+var SkipChildren = errors.New("skip children")
+
+// Introspectable is implemented by all the types of this package, it exposes the structure of composed serdes so
+// tools can walk any spec.
+type Introspectable interface {
+	serdes.Serdes
+	Described
+
+	// Children returns the nested serdes in the order they are encoded, nil for the leaf types.
+	Children() []Child
+
+	// FixedSize returns the number of bytes of the encoded value, false when the size depends on the value.
+	FixedSize() (int, bool)
+}
+
+type ChildKind int
+
+const (
+	// FieldChild is a field of a BitMapped, List, TLV or BerTLV.
+	FieldChild ChildKind = iota
+	// BitmapChild is the bitmap of a BitMapped.
+	BitmapChild
+	// LengthChild is the length prefix of a VarLength.
+	LengthChild
+	// DataChild is the data of a VarLength.
+	DataChild
+)
+
+func (kind ChildKind) String() string {
+	switch kind {
+	case BitmapChild:
+		return "bitmap"
+	case LengthChild:
+		return "length"
+	case DataChild:
+		return "data"
+	}
+	return "field"
+}
+
+// Child is a nested serdes. The Key is the key of its value in the value of the parent: the bit number of a BitMapped
+// field, the name of a List item or the tag of a TLV or BerTLV item. It is empty for the anonymous List items, whose
+// values are merged into the parent, and for the bitmap, length and data children.
+type Child struct {
+	Key    string
+	Kind   ChildKind
+	SerDes serdes.Serdes
+}
+
+// VisitFunc is called by Walk for every serdes of the tree. The path is the path of its value, the keys of the
+// children joined by serdes.PathSeparator, and the parent is nil for the root.
+type VisitFunc func(path string, child Child, parent serdes.Serdes) error
+
+// Walk visits the serdes tree in encoding order, the parents before their children. The serdes that don't implement
+// Introspectable are visited as leaves.
+func Walk(s serdes.Serdes, fn VisitFunc) error {
+	return walk("", Child{SerDes: s}, nil, fn)
+}
+
+func walk(path string, child Child, parent serdes.Serdes, fn VisitFunc) error {
+	if err := fn(path, child, parent); err != nil {
+		if err == SkipChildren {
+			return nil
+		}
+		return err
+	}
+
+	introspectable, ok := child.SerDes.(Introspectable)
+	if !ok {
+		return nil
+	}
+
+	for _, grandChild := range introspectable.Children() {
+		childPath := path
+		if grandChild.Key != "" {
+			childPath = serdes.JoinPath(path, grandChild.Key)
+		}
+
+		if err := walk(childPath, grandChild, child.SerDes, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fieldChildren(items []Field) []Child {
+	children := make([]Child, 0, len(items))
+	for _, item := range items {
+		children = append(children, Child{Key: item.Name, Kind: FieldChild, SerDes: item.SerDes})
+	}
+	return children
+}
+
+func fixedDigits(numDigits int) (int, bool) {
+	return numDigits, numDigits > 0
+}
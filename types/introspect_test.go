@@ -0,0 +1,130 @@
+package types_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Introspectable_FixedSize(t *testing.T) {
+	tests := []struct {
+		serdes types.Introspectable
+		size   int
+		fixed  bool
+	}{
+		{types.Bcd{NumDigits: 3}, 2, true},
+		{types.Bcd{}, 0, false},
+		{types.Ebcdic{NumDigits: 40}, 40, true},
+		{types.EbcdicNumeric{NumDigits: 4}, 4, true},
+		{types.Ascii{NumDigits: 8}, 8, true},
+		{types.AsciiNumeric{}, 0, false},
+		{types.Raw{NumBytes: 8}, 8, true},
+		{types.Byte{}, 1, true},
+		{types.Word{Order: binary.BigEndian}, 2, true},
+		{types.Bitmap{BlockSize: 64, NumBits: 64}, 8, true},
+		{types.Bitmap{BlockSize: 64, NumBits: 64, Hex: true}, 16, true},
+		{types.Bitmap{BlockSize: 64, NumBits: 128}, 0, false},
+		{types.VarLength{Length: types.Byte{}, Data: types.Bcd{}}, 0, false},
+		{types.List{Items: []types.Field{{Name: "a", SerDes: types.Byte{}}, {Name: "b", SerDes: types.Raw{NumBytes: 3}}}}, 4, true},
+		{types.List{Items: []types.Field{{Name: "a", SerDes: types.Byte{}}, {Name: "b", SerDes: types.Raw{}}}}, 0, false},
+		{types.List{}, 0, false},
+		{types.TLV{}, 0, false},
+		{types.BerTLV{}, 0, false},
+		{types.BitMapped{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		size, fixed := tt.serdes.FixedSize()
+		assert.Equal(t, tt.size, size, tt.serdes.Name())
+		assert.Equal(t, tt.fixed, fixed, tt.serdes.Name())
+		assert.Empty(t, tt.serdes.Description())
+	}
+}
+
+func Test_Walk(t *testing.T) {
+	spec := types.List{
+		Desc: "Message",
+		Items: []types.Field{
+			{Name: "mti", SerDes: types.Bcd{Desc: "MTI", NumDigits: 4}},
+			{SerDes: types.BitMapped{
+				Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128},
+				Mapping: map[int]serdes.Serdes{
+					48: types.VarLength{Desc: "Additional data", Length: types.Byte{}, Data: types.TLV{
+						Items: []types.Field{{Name: "92", SerDes: types.Ebcdic{Desc: "CVC 2"}}},
+					}},
+					2:  types.VarLength{Desc: "PAN", Length: types.Byte{}, Data: types.Bcd{}},
+					55: &serdes.Mock{},
+				},
+			}},
+		},
+	}
+
+	type visit struct {
+		path   string
+		kind   types.ChildKind
+		name   string
+		desc   string
+		parent string
+	}
+
+	var visits []visit
+	err := types.Walk(spec, func(path string, child types.Child, parent serdes.Serdes) error {
+		v := visit{path: path, kind: child.Kind, name: child.SerDes.Name()}
+		if described, ok := child.SerDes.(types.Described); ok {
+			v.desc = described.Description()
+		}
+		if parent != nil {
+			v.parent = parent.Name()
+		}
+		visits = append(visits, v)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []visit{
+		{path: "", kind: types.FieldChild, name: "list", desc: "Message"},
+		{path: "mti", kind: types.FieldChild, name: "bcd", desc: "MTI", parent: "list"},
+		{path: "", kind: types.FieldChild, name: "bitMapped", parent: "list"},
+		{path: "", kind: types.BitmapChild, name: "bitmap", parent: "bitMapped"},
+		{path: "2", kind: types.FieldChild, name: "var_length", desc: "PAN", parent: "bitMapped"},
+		{path: "2", kind: types.LengthChild, name: "byte", parent: "var_length"},
+		{path: "2", kind: types.DataChild, name: "bcd", parent: "var_length"},
+		{path: "48", kind: types.FieldChild, name: "var_length", desc: "Additional data", parent: "bitMapped"},
+		{path: "48", kind: types.LengthChild, name: "byte", parent: "var_length"},
+		{path: "48", kind: types.DataChild, name: "tlv", parent: "var_length"},
+		{path: "48.92", kind: types.FieldChild, name: "ebcdic", desc: "CVC 2", parent: "tlv"},
+		{path: "55", kind: types.FieldChild, name: "mock", parent: "bitMapped"},
+	}, visits)
+	assert.Equal(t, "length", types.LengthChild.String())
+}
+
+func Test_Walk_Skip_And_Error(t *testing.T) {
+	spec := types.List{Items: []types.Field{
+		{Name: "a", SerDes: types.VarLength{Length: types.Byte{}, Data: types.Bcd{}}},
+		{Name: "b", SerDes: types.Byte{}},
+	}}
+
+	var paths []string
+	err := types.Walk(spec, func(path string, child types.Child, _ serdes.Serdes) error {
+		paths = append(paths, path)
+		if _, ok := child.SerDes.(types.VarLength); ok {
+			return types.SkipChildren
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "a", "b"}, paths)
+
+	stop := errors.New("stop")
+	err = types.Walk(spec, func(path string, _ types.Child, _ serdes.Serdes) error {
+		if path == "b" {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+}
@@ -17,16 +17,27 @@ func (list List) Name() string {
 }
 
 func (list List) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(list, value)
+}
+
+// AppendSerialize appends the items in order, the anonymous items are serialized with the whole value.
+func (list List) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	return list.appendWith(dst, value, nil)
+}
+
+// appendWith encodes the value like AppendSerialize, the items are encoded by the serdes of the same index when they are
+// given, which encode the values of the serdes of the items.
+func (list List) appendWith(dst []byte, value serdes.Value, items []serdes.Serdes) ([]byte, error) {
 	mapValue, ok := value.(serdes.Map)
 	if !ok {
 		msg := fmt.Sprintf("invalid value [%T], expected: %T", value, serdes.Map{})
-		return nil, SerializerError{
-			Message: msg, Value: value, Serdes: list,
+		return dst, SerializerError{
+			Message: msg, Value: maskValue(list, value), Serdes: list,
 		}
 	}
 
-	serializedData := new(bytes.Buffer)
-	for _, field := range list.Items {
+	start := len(dst)
+	for index, field := range list.Items {
 		var itemValue serdes.Value
 		if field.Name == "" {
 			itemValue = mapValue
@@ -38,53 +49,150 @@ func (list List) Serialize(value serdes.Value) (*bytes.Buffer, error) {
 			itemValue = v
 		}
 
-		data, err := field.SerDes.Serialize(itemValue)
+		encoded, err := serdes.AppendSerialize(dst, itemSerdes(field, items, index), itemValue)
 		if err != nil {
-			return nil, SerializerError{
-				Message: "field serializer failed", Serdes: list, Field: field, Value: value, Cause: err,
-			}
+			return dst[:start], SerializerError{
+				Message: "field serializer failed", Serdes: list, Field: field, Value: maskValue(list, value), Cause: err,
+			}.within(field.Name)
 		}
+		dst = encoded
+	}
+
+	return dst, nil
+}
+
+// Size returns the sum of the sizes of the items of the value.
+func (list List) Size(value serdes.Value) (int, error) {
+	mapValue, ok := value.(serdes.Map)
+	if !ok {
+		msg := fmt.Sprintf("invalid value [%T], expected: %T", value, serdes.Map{})
+		return 0, SerializerError{
+			Message: msg, Value: maskValue(list, value), Serdes: list,
+		}
+	}
 
-		if _, err := serializedData.ReadFrom(data); err != nil {
-			return nil, SerializerError{
-				Message: "buffer failed", Serdes: list, Field: field, Value: value, Cause: err,
+	size := 0
+	for _, field := range list.Items {
+		var itemValue serdes.Value
+		if field.Name == "" {
+			itemValue = mapValue
+		} else {
+			v, itemExists := mapValue[field.Name]
+			if !itemExists {
+				continue
 			}
+			itemValue = v
 		}
+
+		itemSize, err := serdes.Size(field.SerDes, itemValue)
+		if err != nil {
+			return 0, SerializerError{
+				Message: "field serializer failed", Serdes: list, Field: field, Value: maskValue(list, value), Cause: err,
+			}.within(field.Name)
+		}
+		size += itemSize
 	}
 
-	return serializedData, nil
+	return size, nil
 }
 
 func (list List) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	return list.deserializeWith(data, nil)
+}
+
+// deserializeWith decodes the value like Deserialize, see deserializeIntoWith.
+func (list List) deserializeWith(data *bytes.Buffer, items []serdes.Serdes) (serdes.Value, error) {
 	listValues := serdes.Map{}
-	for _, field := range list.Items {
+	if err := list.deserializeIntoWith(data, listValues, items); err != nil {
+		return listValues, err
+	}
+	return listValues, nil
+}
+
+// DeserializeInto clears dst and decodes the items into it.
+func (list List) DeserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	clearMap(dst)
+	return list.deserializeInto(data, dst)
+}
+
+func (list List) deserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	return list.deserializeIntoWith(data, dst, nil)
+}
+
+// deserializeIntoWith decodes the items like deserializeInto, the items are decoded by the serdes of the same index
+// when they are given.
+func (list List) deserializeIntoWith(data *bytes.Buffer, dst serdes.Map, items []serdes.Serdes) error {
+	size := data.Len()
+	for index, field := range list.Items {
 		if data.Len() == 0 {
 			break
 		}
 
-		fieldValue, err := field.SerDes.Deserialize(data)
+		offset := size - data.Len()
+		item := itemSerdes(field, items, index)
+
+		if into, ok := item.(intoDeserializer); ok && field.Name == "" {
+			if err := into.deserializeInto(data, dst); err != nil {
+				return DeserializationError{
+					Message: "field deserializer failed", Serdes: list, Field: field, Remaning: data.Len(), Cause: err,
+				}.within(field.Name, offset)
+			}
+			continue
+		}
+
+		fieldValue, err := item.Deserialize(data)
 		if err != nil {
-			return listValues, DeserializationError{
+			return DeserializationError{
 				Message: "field deserializer failed", Serdes: list, Field: field, Remaning: data.Len(), Cause: err,
-			}
+			}.within(field.Name, offset)
 		}
 
 		if field.Name != "" {
-			listValues[field.Name] = fieldValue
+			dst[field.Name] = fieldValue
 			continue
 		}
 
 		mapValue, ok := fieldValue.(serdes.Map)
 		if !ok {
-			return listValues, DeserializationError{
+			return DeserializationError{
 				Message: "field deserializer failed, anonymous field requires a map value", Serdes: list, Field: field, Remaning: data.Len(), Cause: err,
-			}
+			}.within(field.Name, offset)
 		}
 
 		for mapKep, mapItem := range mapValue {
-			listValues[mapKep] = mapItem
+			dst[mapKep] = mapItem
 		}
 	}
 
-	return listValues, nil
+	return nil
+}
+
+// itemSerdes returns the serdes of the index when the items are given, otherwise the serdes of the field.
+func itemSerdes(field Field, items []serdes.Serdes, index int) serdes.Serdes {
+	if items != nil {
+		return items[index]
+	}
+	return field.SerDes
+}
+
+func (list List) Children() []Child {
+	return fieldChildren(list.Items)
+}
+
+// FixedSize returns the sum of the sizes of the items when all of them have a fixed size.
+func (list List) FixedSize() (int, bool) {
+	total := 0
+	for _, item := range list.Items {
+		introspectable, ok := item.SerDes.(Introspectable)
+		if !ok {
+			return 0, false
+		}
+
+		size, fixed := introspectable.FixedSize()
+		if !fixed {
+			return 0, false
+		}
+		total += size
+	}
+	return total, len(list.Items) > 0
 }
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+)
+
+// Mask marks the values of a field as sensitive, the errors of the types carry the masked values of the sensitive
+// fields instead of the values.
+type Mask string
+
+const (
+	// NoMask is the mask of the fields that are not sensitive.
+	NoMask Mask = ""
+	// MaskPAN keeps the first 6 and the last 4 characters of the values, like 476173******0010. The values shorter
+	// than 11 characters are fully masked.
+	MaskPAN Mask = "pan"
+	// MaskAll masks every character of the values, for track data, PIN blocks and CVVs.
+	MaskAll Mask = "all"
+)
+
+const (
+	_maskChar      = "*"
+	_panFirstChars = 6
+	_panLastChars  = 4
+)
+
+// errMasked stops the walk of a serdes with a sensitive field.
+var errMasked = errors.New("masked")
+
+// Masker is implemented by every type that embeds Mask.
+type Masker interface {
+	Sensitive() bool
+	MaskValue(value serdes.Value) serdes.Value
+}
+
+// Sensitive returns true when the values are masked.
+func (mask Mask) Sensitive() bool {
+	return mask != NoMask
+}
+
+// MaskValue returns the masked value, the values that are not strings are masked as their fmt representation.
+func (mask Mask) MaskValue(value serdes.Value) serdes.Value {
+	if !mask.Sensitive() || value == nil {
+		return value
+	}
+
+	valueStr, ok := value.(string)
+	if !ok {
+		valueStr = fmt.Sprint(value)
+	}
+
+	chars := []rune(valueStr)
+	if mask != MaskPAN || len(chars) <= _panFirstChars+_panLastChars {
+		return strings.Repeat(_maskChar, len(chars))
+	}
+
+	numMasked := len(chars) - _panFirstChars - _panLastChars
+	return string(chars[:_panFirstChars]) + strings.Repeat(_maskChar, numMasked) + string(chars[len(chars)-_panLastChars:])
+}
+
+// maskValue returns the value of the serdes with the values of its sensitive fields masked, the maps are copied
+// before masking their items.
+func maskValue(s serdes.Serdes, value serdes.Value) serdes.Value {
+	if value == nil || !hasMask(s) {
+		return value
+	}
+
+	if masker, ok := s.(Masker); ok && masker.Sensitive() {
+		return masker.MaskValue(value)
+	}
+
+	introspectable, ok := s.(Introspectable)
+	if !ok {
+		return value
+	}
+
+	children := make(map[string]serdes.Serdes)
+	for _, child := range introspectable.Children() {
+		if child.Kind == BitmapChild || child.Kind == LengthChild {
+			continue
+		}
+
+		if child.Key == "" {
+			// the anonymous items and the data of VarLength have the value of the parent.
+			value = maskValue(child.SerDes, value)
+			continue
+		}
+		children[child.Key] = child.SerDes
+	}
+
+	mapValue, ok := value.(serdes.Map)
+	if !ok {
+		return value
+	}
+
+	_, isBitMapped := s.(BitMapped)
+	masked := make(serdes.Map, len(mapValue))
+	for key, item := range mapValue {
+		childKey := key
+		if bit, err := strconv.Atoi(key); err == nil && isBitMapped {
+			childKey = strconv.Itoa(bit)
+		}
+
+		if child, exists := children[childKey]; exists {
+			item = maskValue(child, item)
+		}
+		masked[key] = item
+	}
+	return masked
+}
+
+// hasMask returns true when the serdes or any of its children is sensitive.
+func hasMask(s serdes.Serdes) bool {
+	err := Walk(s, func(path string, child Child, parent serdes.Serdes) error {
+		if masker, ok := child.SerDes.(Masker); ok && masker.Sensitive() {
+			return errMasked
+		}
+		return nil
+	})
+	return err == errMasked
+}
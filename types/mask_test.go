@@ -0,0 +1,111 @@
+package types_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Mask_MaskValue(t *testing.T) {
+	tests := []struct {
+		mask     types.Mask
+		value    serdes.Value
+		expected serdes.Value
+	}{
+		{types.NoMask, "4761739001010010", "4761739001010010"},
+		{types.MaskPAN, "4761739001010010", "476173******0010"},
+		{types.MaskPAN, "47617390010", "476173*0010"},
+		{types.MaskPAN, "4761739001", "**********"},
+		{types.MaskAll, "4761739001010010=2512", "*********************"},
+		{types.MaskAll, 1234, "****"},
+		{types.MaskAll, nil, nil},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.mask.MaskValue(tt.value))
+	}
+}
+
+func maskedSpec() types.List {
+	return types.List{Items: []types.Field{
+		{Name: "mti", SerDes: types.AsciiNumeric{NumDigits: 4}},
+		{SerDes: types.BitMapped{
+			Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128},
+			Mapping: map[int]serdes.Serdes{
+				2:  types.VarLength{Length: types.Byte{}, Data: types.Bcd{Mask: types.MaskPAN}},
+				3:  types.AsciiNumeric{NumDigits: 6},
+				35: types.VarLength{Length: types.Byte{}, Data: types.Ebcdic{Mask: types.MaskAll}},
+				55: types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: types.BerTLV{Items: []types.Field{
+					{Name: "57", SerDes: types.Raw{Mask: types.MaskAll}},
+				}}},
+			},
+		}},
+	}}
+}
+
+func Test_Mask_Errors(t *testing.T) {
+	message := serdes.Map{
+		"mti": "0100",
+		"02":  "4761739001010010",
+		"3":   "0000001",
+		"35":  "4761739001010010=2512",
+		"55":  serdes.Map{"57": "4761739001010010d2512"},
+	}
+
+	_, serializeErr := maskedSpec().Serialize(message)
+	assert.True(t, errors.Is(serializeErr, types.ErrValueTooLong))
+
+	var serializerErr types.SerializerError
+	assert.True(t, errors.As(serializeErr, &serializerErr))
+	assert.Equal(t, serdes.Map{
+		"mti": "0100",
+		"02":  "476173******0010",
+		"3":   "0000001",
+		"35":  "*********************",
+		"55":  serdes.Map{"57": "*********************"},
+	}, serializerErr.Value)
+	assert.Equal(t, "4761739001010010", message["02"])
+
+	encoded, err := json.Marshal(serializeErr)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encoded), "4761739001010010")
+
+	plan, err := types.Compile(maskedSpec())
+	assert.NoError(t, err)
+	_, planErr := plan.Serialize(message)
+	assert.Equal(t, serializeErr, planErr)
+
+	tests := []struct {
+		serdes serdes.Serdes
+		value  serdes.Value
+	}{
+		{types.Bcd{NumDigits: 12, Mask: types.MaskPAN}, "4761739001010010"},
+		{types.Bcd{Mask: types.MaskPAN}, "476173900101001x"},
+		{types.Ebcdic{NumDigits: 12, Mask: types.MaskPAN}, "4761739001010010"},
+		{types.EbcdicNumeric{NumDigits: 12, Mask: types.MaskPAN}, "4761739001010010"},
+		{types.Ascii{NumDigits: 12, Mask: types.MaskPAN}, "4761739001010010"},
+		{types.AsciiNumeric{NumDigits: 12, Mask: types.MaskPAN}, "4761739001010010"},
+		{types.Raw{Mask: types.MaskPAN}, "4761739001010010x"},
+		{types.VarLength{Length: types.Byte{}, Data: types.Ascii{NumDigits: 12, Mask: types.MaskPAN}}, "4761739001010010"},
+		{types.Byte{Mask: types.MaskAll}, "4761739001010010x"},
+		{types.Word{Mask: types.MaskAll, Order: binary.BigEndian}, "4761739001010010x"},
+		{types.Word{Mask: types.MaskAll}, "4761739001010010"},
+	}
+
+	for _, tt := range tests {
+		_, err := tt.serdes.Serialize(tt.value)
+		assert.True(t, errors.As(err, &serializerErr), tt.serdes.Name())
+		assert.NotContains(t, serializerErr.Value, "739001", tt.serdes.Name())
+		assert.NotContains(t, err.Error(), "739001", tt.serdes.Name())
+
+		_, err = serdes.Size(tt.serdes, tt.value)
+		assert.True(t, errors.As(err, &serializerErr), tt.serdes.Name())
+		assert.NotContains(t, serializerErr.Value, "739001", tt.serdes.Name())
+	}
+}
@@ -0,0 +1,239 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+)
+
+// DeserializePartial decodes the value like Deserialize, but it doesn't stop at the first field that fails. The fields
+// whose end is known, the fixed size fields, the data of the VarLength fields and the TLV and BerTLV tags, are skipped
+// and the decoding continues with the next field. It returns the decoded value, with the maps of the composite serdes
+// filled with the fields that could be decoded, and the errors of the fields in encoding order.
+//
+// The decoding stops at the fields whose end is unknown, like the bits without mapping or the fields longer than the
+// data, and the bytes from the start of those fields are not consumed.
+func DeserializePartial(s serdes.Serdes, data *bytes.Buffer) (serdes.Value, []error) {
+	value, size, errs, _ := deserializePartial(s, data.Bytes())
+	data.Next(size)
+	return value, errs
+}
+
+// deserializePartial returns the value decoded from data, nil when it could not be decoded, the size of the value,
+// the errors of its fields and false when the end of the value is unknown, in which case the size is the one of the
+// fields decoded before the failing field.
+func deserializePartial(s serdes.Serdes, data []byte) (serdes.Value, int, []error, bool) {
+	switch value := s.(type) {
+	case VarLength:
+		return value.deserializePartial(data)
+	case List:
+		return value.deserializePartial(data)
+	case BitMapped:
+		return value.deserializePartial(data)
+	case TLV:
+		return value.deserializePartial(data)
+	case BerTLV:
+		return value.deserializePartial(data)
+	}
+
+	buffer := bytes.NewBuffer(data)
+	value, err := s.Deserialize(buffer)
+	if err == nil {
+		return value, len(data) - buffer.Len(), nil, true
+	}
+
+	if introspectable, ok := s.(Introspectable); ok {
+		if size, fixed := introspectable.FixedSize(); fixed && size <= len(data) {
+			return nil, size, []error{err}, true
+		}
+	}
+	return nil, 0, []error{err}, false
+}
+
+// deserializePartial stops at a length that is not a number or is longer than the data, the end of the field is unknown.
+func (varLen VarLength) deserializePartial(data []byte) (serdes.Value, int, []error, bool) {
+	buffer := bytes.NewBuffer(data)
+	serializedData, err := varLen.next(buffer)
+	if err != nil {
+		return nil, 0, []error{err}, false
+	}
+
+	size := len(data) - buffer.Len()
+	if serializedData.Len() == 0 {
+		return serializedData, size, nil, true
+	}
+
+	offset := size - serializedData.Len()
+	value, _, dataErrs, _ := deserializePartial(varLen.Data, serializedData.Bytes())
+
+	errs := make([]error, 0, len(dataErrs))
+	for _, err := range dataErrs {
+		errs = append(errs, DeserializationError{
+			Message: "deserializer failed", Serdes: varLen, Cause: err,
+		}.within("", offset))
+	}
+	return value, size, errs, true
+}
+
+func (list List) deserializePartial(data []byte) (serdes.Value, int, []error, bool) {
+	values := serdes.Map{}
+	var errs []error
+	offset := 0
+	for _, field := range list.Items {
+		if offset == len(data) {
+			break
+		}
+
+		value, size, fieldErrs, ok := deserializePartial(field.SerDes, data[offset:])
+		for _, err := range fieldErrs {
+			errs = append(errs, DeserializationError{
+				Message: "field deserializer failed", Serdes: list, Field: field, Cause: err,
+			}.within(field.Name, offset))
+		}
+
+		if value != nil && field.Name != "" {
+			values[field.Name] = value
+		} else if value != nil {
+			mapValue, isMap := value.(serdes.Map)
+			if !isMap {
+				errs = append(errs, DeserializationError{
+					Message: "field deserializer failed, anonymous field requires a map value", Serdes: list, Field: field,
+				}.within(field.Name, offset))
+			}
+
+			for key, item := range mapValue {
+				values[key] = item
+			}
+		}
+
+		offset += size
+		if !ok {
+			return values, offset, errs, false
+		}
+	}
+
+	return values, offset, errs, true
+}
+
+func (bitMapped BitMapped) deserializePartial(data []byte) (serdes.Value, int, []error, bool) {
+	values := serdes.Map{}
+	buffer := bytes.NewBuffer(data)
+	bitmapValue, err := bitMapped.Bitmap.Deserialize(buffer)
+	if err != nil {
+		return values, 0, []error{DeserializationError{
+			Message: "error decoding bitmap", Serdes: bitMapped, Cause: err,
+		}.within("", 0)}, false
+	}
+
+	bitmap, ok := bitmapValue.([]byte)
+	if !ok {
+		return values, 0, []error{DeserializationError{
+			Message: "bitmap was deserialized to an invalid type", Serdes: bitMapped,
+		}}, false
+	}
+
+	var errs []error
+	offset := len(data) - buffer.Len()
+	for bitNumber := 1; bitNumber <= len(bitmap)*8; bitNumber++ {
+		if !bitMapped.checkBit(bitmap, bitNumber-1) {
+			continue
+		}
+
+		bitKey := strconv.Itoa(bitNumber)
+		deserializer, exists := bitMapped.Mapping[bitNumber]
+		if !exists || deserializer == nil {
+			errs = append(errs, DeserializationError{
+				Message: fmt.Sprintf("bit %d not found", bitNumber), Serdes: bitMapped, Err: ErrUnknownBit,
+			}.within(bitKey, offset))
+			return values, offset, errs, false
+		}
+
+		value, size, fieldErrs, ok := deserializePartial(deserializer, data[offset:])
+		for _, err := range fieldErrs {
+			errs = append(errs, DeserializationError{
+				Message: fmt.Sprintf("deserialize bit %d failed", bitNumber), Serdes: bitMapped,
+				Field: Field{Name: bitKey, SerDes: deserializer}, Cause: err,
+			}.within(bitKey, offset))
+		}
+
+		if value != nil {
+			values[bitKey] = value
+		}
+
+		offset += size
+		if !ok {
+			return values, offset, errs, false
+		}
+	}
+
+	return values, offset, errs, true
+}
+
+// deserializePartial decodes the tags before the first tag that fails, the tags that are not listed in the items are
+// decoded as text like Deserialize does.
+func (t TLV) deserializePartial(data []byte) (serdes.Value, int, []error, bool) {
+	mapTLV, offsets, tagsErr := t.decode(data)
+	if tagsErr != nil {
+		mapTLV, offsets, _ = t.decode(data[:offsets[len(offsets)-1]])
+	}
+
+	values := serdes.Map{}
+	var errs []error
+	for index, tlv := range mapTLV {
+		tagValue := t.tagKey(tlv.Tag)
+		field := t.tagField(tagValue)
+
+		value, _, fieldErrs, _ := deserializePartial(field.SerDes, tlv.Value)
+		for _, err := range fieldErrs {
+			errs = append(errs, DeserializationError{
+				Message: "struct data deserializer failed", Serdes: t, Field: field, Cause: err,
+			}.within(tagValue, offsets[index+1]-len(tlv.Value)))
+		}
+
+		if value != nil {
+			values[tagValue] = value
+		}
+	}
+
+	if tagsErr != nil {
+		errs = append(errs, tagsError(t, offsets, tagsErr))
+	}
+	return values, len(data), errs, true
+}
+
+// deserializePartial decodes the listed tags before the first tag that fails.
+func (t BerTLV) deserializePartial(data []byte) (serdes.Value, int, []error, bool) {
+	mapTLV, offsets, tagsErr := decodeOffsets(t.SizeLen, data)
+	if tagsErr != nil {
+		mapTLV, offsets, _ = decodeOffsets(t.SizeLen, data[:offsets[len(offsets)-1]])
+	}
+
+	values := serdes.Map{}
+	var errs []error
+	for index, tlv := range mapTLV {
+		tagValue := hex.EncodeToString(encodeInt(tlv.Tag))
+		field, err := t.findField(tagValue)
+		if err != nil {
+			continue
+		}
+
+		value, _, fieldErrs, _ := deserializePartial(field.SerDes, tlv.Value)
+		for _, err := range fieldErrs {
+			errs = append(errs, DeserializationError{
+				Message: "struct data deserializer failed", Serdes: t, Field: field, Cause: err,
+			}.within(tagValue, offsets[index+1]-len(tlv.Value)))
+		}
+
+		if value != nil {
+			values[tagValue] = value
+		}
+	}
+
+	if tagsErr != nil {
+		errs = append(errs, tagsError(t, offsets, tagsErr))
+	}
+	return values, len(data), errs, true
+}
@@ -0,0 +1,153 @@
+package types_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func partialSpec(name types.Ascii, tag types.EbcdicNumeric) types.List {
+	return types.List{Items: []types.Field{
+		{Name: "mti", SerDes: types.AsciiNumeric{NumDigits: 4}},
+		{SerDes: types.BitMapped{
+			Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128},
+			Mapping: map[int]serdes.Serdes{
+				2:  types.VarLength{Length: types.Byte{}, Data: types.Bcd{}},
+				3:  types.AsciiNumeric{NumDigits: 6},
+				43: types.VarLength{Length: types.Byte{}, Data: name},
+				48: types.VarLength{Length: types.AsciiNumeric{NumDigits: 3}, Data: types.TLV{Items: []types.Field{
+					{Name: "21", SerDes: tag},
+					{Name: "61", SerDes: types.Ebcdic{}},
+				}}},
+				70: types.AsciiNumeric{NumDigits: 3},
+			},
+		}},
+	}}
+}
+
+func Test_DeserializePartial(t *testing.T) {
+	message := serdes.Map{
+		"mti": "0100",
+		"2":   "4761739001010010",
+		"3":   "000000",
+		"43":  "SHOP",
+		"48":  serdes.Map{"21": "1", "61": "X"},
+		"70":  "301",
+	}
+
+	// the spec of the message has no sizes, the strict spec expects 10 characters in the field 43 and 2 digits in the
+	// tag 21 of the field 48.
+	data, err := partialSpec(types.Ascii{}, types.EbcdicNumeric{}).Serialize(message)
+	assert.NoError(t, err)
+	strict := partialSpec(types.Ascii{NumDigits: 10}, types.EbcdicNumeric{NumDigits: 2})
+
+	_, err = strict.Deserialize(bytes.NewBuffer(data.Bytes()))
+	assert.Error(t, err)
+
+	value, errs := types.DeserializePartial(strict, data)
+	assert.Equal(t, serdes.Map{
+		"mti": "0100",
+		"2":   "4761739001010010",
+		"3":   "000000",
+		"48":  serdes.Map{"61": "X"},
+		"70":  "301",
+	}, value)
+	assert.Equal(t, 0, data.Len())
+
+	assert.Len(t, errs, 2)
+	expected := []struct {
+		path   string
+		offset int
+	}{
+		{"43", 36},
+		{"48.21", 47},
+	}
+	for index, err := range errs {
+		var deserializationErr types.DeserializationError
+		assert.True(t, errors.As(err, &deserializationErr))
+		assert.True(t, errors.Is(err, types.ErrShortBuffer))
+		assert.Equal(t, expected[index].path, deserializationErr.Path)
+		assert.Equal(t, expected[index].offset, deserializationErr.Offset)
+	}
+}
+
+func Test_DeserializePartial_Stops(t *testing.T) {
+	spec := partialSpec(types.Ascii{}, types.EbcdicNumeric{})
+
+	// the bit 4 has no field, its size is unknown.
+	data := append([]byte("0100"), 0x30, 0, 0, 0, 0, 0, 0, 0)
+	data = append(data, "000000000000001000"...)
+	buffer := bytes.NewBuffer(data)
+
+	value, errs := types.DeserializePartial(spec, buffer)
+	assert.Equal(t, serdes.Map{"mti": "0100", "3": "000000"}, value)
+	assert.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0], types.ErrUnknownBit))
+	assert.Equal(t, "000000001000", buffer.String())
+
+	// the field 3 is longer than the data.
+	buffer = bytes.NewBuffer(data[:15])
+	value, errs = types.DeserializePartial(spec, buffer)
+	assert.Equal(t, serdes.Map{"mti": "0100"}, value)
+	assert.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0], types.ErrShortBuffer))
+	assert.Equal(t, 3, buffer.Len())
+}
+
+func Test_DeserializePartial_Valid(t *testing.T) {
+	data, err := appendSpec().Serialize(appendMessage())
+	assert.NoError(t, err)
+
+	expected, err := appendSpec().Deserialize(bytes.NewBuffer(data.Bytes()))
+	assert.NoError(t, err)
+
+	value, errs := types.DeserializePartial(appendSpec(), data)
+	assert.Nil(t, errs)
+	assert.Equal(t, expected, value)
+	assert.Equal(t, 0, data.Len())
+}
+
+func Test_DeserializePartial_Invalid_Length(t *testing.T) {
+	spec := types.List{Items: []types.Field{
+		{Name: "mti", SerDes: types.AsciiNumeric{NumDigits: 4}},
+		{SerDes: types.BitMapped{
+			Bitmap: types.Bitmap{BlockSize: 64, NumBits: 64},
+			Mapping: map[int]serdes.Serdes{
+				2: types.VarLength{Length: types.Ascii{NumDigits: 2}, Data: types.Ascii{}},
+				3: types.AsciiNumeric{NumDigits: 6},
+			},
+		}},
+	}}
+	bitmap := string([]byte{0x60, 0, 0, 0, 0, 0, 0, 0})
+
+	tests := []struct {
+		name   string
+		length string
+		err    error
+		offset int
+	}{
+		{name: "negative length", length: "-1", err: types.ErrInvalidCharacter, offset: 12},
+		{name: "non numeric length", length: "AB", err: types.ErrInvalidCharacter, offset: 12},
+		{name: "oversized length", length: "99", err: types.ErrShortBuffer, offset: 14},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := bytes.NewBufferString("0100" + bitmap + tt.length + "4761" + "000000")
+
+			value, errs := types.DeserializePartial(spec, data)
+			assert.Equal(t, serdes.Map{"mti": "0100"}, value)
+			if assert.Len(t, errs, 1) {
+				assert.ErrorIs(t, errs[0], tt.err)
+				var desErr types.DeserializationError
+				assert.ErrorAs(t, errs[0], &desErr)
+				assert.Equal(t, "2", desErr.Path)
+				assert.Equal(t, tt.offset, desErr.Offset)
+			}
+		})
+	}
+}
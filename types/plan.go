@@ -0,0 +1,365 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+)
+
+// Plan is a spec compiled into an execution plan. The type of every serdes of the tree is resolved once, so the
+// composite types don't inspect their children on every message, and the fixed sizes and the offsets of the fixed size
+// fields are precomputed. The values are encoded and decoded by the types of the spec, so the plan produces the same
+// bytes, values and errors of the spec.
+//
+// A Plan is immutable, it can be used by many goroutines at the same time. Changes to the spec after compiling it are
+// not seen by the plan.
+type Plan struct {
+	spec    serdes.Serdes
+	root    serdes.Serdes
+	size    int
+	offsets map[string]int
+}
+
+// planVarLength, planList and planBitMapped encode and decode the values of their types with the compiled serdes of
+// their children, the other serdes are used as they are.
+type planVarLength struct {
+	VarLength
+	length  serdes.Serdes
+	data    serdes.Serdes
+	bcdData bool
+}
+
+type planList struct {
+	List
+	items []serdes.Serdes
+}
+
+type planBitMapped struct {
+	BitMapped
+	fields []serdes.Serdes // fields by bit number.
+}
+
+// Compile returns the execution plan of the spec. It fails when the spec has a nil serdes, a bitmap whose block size
+// is not a positive multiple of 8, or an item tag that cannot be encoded.
+func Compile(spec serdes.Serdes) (*Plan, error) {
+	spec = copySpec(spec)
+	root, err := compile("", spec)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{spec: spec, root: root, size: planSize(spec), offsets: map[string]int{}}
+	plan.computeOffsets("", spec, 0)
+	return plan, nil
+}
+
+// copySpec returns a copy of the spec that doesn't share the mappings and the items of the composite types, the plan is
+// not changed by the changes to the spec.
+func copySpec(s serdes.Serdes) serdes.Serdes {
+	switch value := s.(type) {
+	case VarLength:
+		value.Length, value.Data = copySpec(value.Length), copySpec(value.Data)
+		return value
+	case List:
+		value.Items = copyItems(value.Items)
+		return value
+	case BitMapped:
+		if value.Mapping == nil {
+			return value
+		}
+
+		mapping := make(map[int]serdes.Serdes, len(value.Mapping))
+		for bit, field := range value.Mapping {
+			mapping[bit] = copySpec(field)
+		}
+		value.Mapping = mapping
+		return value
+	case TLV:
+		value.Items = copyItems(value.Items)
+		return value
+	case BerTLV:
+		value.Items = copyItems(value.Items)
+		return value
+	}
+	return s
+}
+
+func copyItems(items []Field) []Field {
+	if items == nil {
+		return nil
+	}
+
+	copied := make([]Field, 0, len(items))
+	for _, field := range items {
+		copied = append(copied, Field{Name: field.Name, SerDes: copySpec(field.SerDes)})
+	}
+	return copied
+}
+
+func compile(path string, s serdes.Serdes) (serdes.Serdes, error) {
+	if s == nil {
+		return nil, planError(path, "nil serdes")
+	}
+
+	switch value := s.(type) {
+	case VarLength:
+		return compileVarLength(path, value)
+	case List:
+		return compileList(path, value)
+	case BitMapped:
+		return compileBitMapped(path, value)
+	case TLV:
+		return s, compileTLV(path, value)
+	case BerTLV:
+		return s, compileBerTLV(path, value)
+	}
+	return s, nil
+}
+
+func compileVarLength(path string, varLen VarLength) (serdes.Serdes, error) {
+	length, err := compile(path, varLen.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := compile(path, varLen.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	_, bcdData := varLen.Data.(Bcd)
+	return planVarLength{VarLength: varLen, length: length, data: data, bcdData: bcdData}, nil
+}
+
+func compileList(path string, list List) (serdes.Serdes, error) {
+	items := make([]serdes.Serdes, 0, len(list.Items))
+	for _, field := range list.Items {
+		item, err := compile(serdes.JoinPath(path, field.Name), field.SerDes)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return planList{List: list, items: items}, nil
+}
+
+func compileBitMapped(path string, bitMapped BitMapped) (serdes.Serdes, error) {
+	bitmap := bitMapped.Bitmap
+	if bitmap.BlockSize <= 0 || bitmap.BlockSize%8 != 0 {
+		return nil, planError(path, fmt.Sprintf("invalid bitmap block size %d", bitmap.BlockSize))
+	}
+
+	maxBit := bitmap.NumBits
+	for bit := range bitMapped.Mapping {
+		if bit < 1 {
+			return nil, planError(path, fmt.Sprintf("invalid bit number %d", bit))
+		}
+		if bit > maxBit {
+			maxBit = bit
+		}
+	}
+
+	fields := make([]serdes.Serdes, maxBit+1)
+	for bit := 1; bit <= maxBit; bit++ {
+		fieldSerdes, exists := bitMapped.Mapping[bit]
+		if !exists || fieldSerdes == nil {
+			continue
+		}
+
+		field, err := compile(serdes.JoinPath(path, strconv.Itoa(bit)), fieldSerdes)
+		if err != nil {
+			return nil, err
+		}
+		fields[bit] = field
+	}
+	return planBitMapped{BitMapped: bitMapped, fields: fields}, nil
+}
+
+// compileTLV checks the tags and the items of the TLV, the TLV decodes its tags with the serdes of the items.
+func compileTLV(path string, tlv TLV) error {
+	sizeTag, _ := tlv.sizes()
+	for _, field := range tlv.Items {
+		if field.Name == "" {
+			// the unnamed items fail when serialized, the plan keeps the TLV behavior.
+			continue
+		}
+
+		itemPath := serdes.JoinPath(path, field.Name)
+		if _, err := tlv.appendTag(nil, sizeTag, field.Name); err != nil {
+			return planError(itemPath, "invalid tag")
+		}
+
+		if _, err := compile(itemPath, field.SerDes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compileBerTLV checks the tags and the items of the BerTLV like compileTLV.
+func compileBerTLV(path string, berTLV BerTLV) error {
+	for _, field := range berTLV.Items {
+		if field.Name == "" {
+			continue
+		}
+
+		itemPath := serdes.JoinPath(path, field.Name)
+		tagRaw, err := Raw{}.appendString(nil, field.Name)
+		if err != nil {
+			return planError(itemPath, "invalid tag")
+		}
+
+		if _, _, err := parseTag(tagRaw); err != nil {
+			return planError(itemPath, "invalid tag")
+		}
+
+		if _, err := compile(itemPath, field.SerDes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// computeOffsets records the offsets of the named fields of the lists while all the previous fields have a fixed size,
+// it returns false when the size of the serdes depends on the value.
+func (plan *Plan) computeOffsets(path string, s serdes.Serdes, offset int) bool {
+	list, ok := s.(List)
+	if !ok {
+		return planSize(s) > 0
+	}
+
+	for _, item := range list.Items {
+		itemPath := path
+		if item.Name != "" {
+			itemPath = serdes.JoinPath(path, item.Name)
+			plan.offsets[itemPath] = offset
+		}
+
+		if !plan.computeOffsets(itemPath, item.SerDes, offset) {
+			return false
+		}
+		offset += planSize(item.SerDes)
+	}
+	return planSize(s) > 0
+}
+
+// planSize returns the fixed size of the serdes, 0 when it depends on the value.
+func planSize(s serdes.Serdes) int {
+	if introspectable, ok := s.(Introspectable); ok {
+		if size, fixed := introspectable.FixedSize(); fixed {
+			return size
+		}
+	}
+	return 0
+}
+
+func planError(path, message string) error {
+	if path == "" {
+		return fmt.Errorf("compile error: %s", message)
+	}
+	return fmt.Errorf("compile error: %s: path: %s", message, path)
+}
+
+// Spec returns the compiled spec.
+func (plan *Plan) Spec() serdes.Serdes {
+	return plan.spec
+}
+
+func (plan *Plan) Name() string {
+	return plan.spec.Name()
+}
+
+// FixedSize returns the size of the messages of the plan when it doesn't depend on the value.
+func (plan *Plan) FixedSize() (int, bool) {
+	return plan.size, plan.size > 0
+}
+
+// Offset returns the offset of the field of the path from the start of the message. Only the fields of the lists
+// whose previous fields have a fixed size have an offset, like the fields of a header and the MTI.
+func (plan *Plan) Offset(path string) (int, bool) {
+	offset, ok := plan.offsets[path]
+	return offset, ok
+}
+
+// Size returns the size of the encoded value.
+func (plan *Plan) Size(value serdes.Value) (int, error) {
+	return serdes.Size(plan.root, value)
+}
+
+func (plan *Plan) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(plan, value)
+}
+
+func (plan *Plan) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	return serdes.AppendSerialize(dst, plan.root, value)
+}
+
+// Deserialize decodes the message and consumes its bytes from data.
+func (plan *Plan) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	return plan.root.Deserialize(data)
+}
+
+// DeserializeInto clears dst and decodes the message into it, the root of the spec must decode a map.
+func (plan *Plan) DeserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	return serdes.DeserializeInto(data, plan.root, dst)
+}
+
+func (varLen planVarLength) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(varLen, value)
+}
+
+func (varLen planVarLength) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	return varLen.VarLength.appendWith(dst, value, varLen.length, varLen.data, varLen.bcdData)
+}
+
+func (varLen planVarLength) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	return varLen.VarLength.deserializeWith(data, varLen.length, varLen.data, varLen.bcdData)
+}
+
+func (varLen planVarLength) DeserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	return varLen.VarLength.deserializeIntoWith(data, dst, varLen.length, varLen.data, varLen.bcdData)
+}
+
+func (list planList) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(list, value)
+}
+
+func (list planList) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	return list.List.appendWith(dst, value, list.items)
+}
+
+func (list planList) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	return list.List.deserializeWith(data, list.items)
+}
+
+func (list planList) DeserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	clearMap(dst)
+	return list.deserializeInto(data, dst)
+}
+
+func (list planList) deserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	return list.List.deserializeIntoWith(data, dst, list.items)
+}
+
+func (bitMapped planBitMapped) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(bitMapped, value)
+}
+
+func (bitMapped planBitMapped) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	return bitMapped.BitMapped.appendWith(dst, value, bitMapped.fields)
+}
+
+func (bitMapped planBitMapped) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
+	return bitMapped.BitMapped.deserializeWith(data, bitMapped.fields)
+}
+
+func (bitMapped planBitMapped) DeserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	clearMap(dst)
+	return bitMapped.deserializeInto(data, dst)
+}
+
+func (bitMapped planBitMapped) deserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	return bitMapped.BitMapped.deserializeIntoWith(data, dst, bitMapped.fields)
+}
@@ -0,0 +1,294 @@
+package types_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/specs"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Compile(t *testing.T) {
+	spec := appendSpec()
+	plan, err := types.Compile(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, spec.Name(), plan.Name())
+	assert.Equal(t, spec, plan.Spec())
+
+	messages := []serdes.Map{
+		appendMessage(),
+		{"mti": "0800", "11": "000001", "70": "301"},
+		{"mti": "0100", "2": "476173900101001", "48": serdes.Map{"tcc": "R"}},
+		{"mti": "0100", "03": "000000", "3": "000000"},
+		{"mti": "0100", "x": "ignored", "4": "000000001000"},
+		{"mti": "0100"},
+	}
+
+	for _, message := range messages {
+		expected, err := spec.Serialize(message)
+		assert.NoError(t, err)
+
+		data, err := plan.AppendSerialize([]byte("prefix"), message)
+		assert.NoError(t, err)
+		assert.Equal(t, append([]byte("prefix"), expected.Bytes()...), data)
+
+		size, err := plan.Size(message)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Len(), size)
+
+		expectedBuffer := bytes.NewBuffer(expected.Bytes())
+		expectedValue, expectedErr := spec.Deserialize(expectedBuffer)
+		buffer := bytes.NewBuffer(expected.Bytes())
+		value, err := plan.Deserialize(buffer)
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, expectedValue, value)
+		assert.Equal(t, expectedBuffer.Len(), buffer.Len())
+	}
+}
+
+func Test_Compile_Errors(t *testing.T) {
+	tests := []struct {
+		spec serdes.Serdes
+		err  string
+	}{
+		{nil, "compile error: nil serdes"},
+		{types.List{Items: []types.Field{{Name: "mti"}}}, "compile error: nil serdes: path: mti"},
+		{types.BitMapped{Bitmap: types.Bitmap{BlockSize: 12, NumBits: 24}}, "compile error: invalid bitmap block size 12"},
+		{types.BitMapped{Bitmap: types.Bitmap{BlockSize: 64, NumBits: 64}, Mapping: map[int]serdes.Serdes{
+			2: types.VarLength{Length: types.Byte{}},
+		}}, "compile error: nil serdes: path: 2"},
+		{types.TLV{Items: []types.Field{{Name: "123", SerDes: types.Ebcdic{}}}}, "compile error: invalid tag: path: 123"},
+		{types.BerTLV{Items: []types.Field{{Name: "zz", SerDes: types.Raw{}}}}, "compile error: invalid tag: path: zz"},
+	}
+
+	for _, tt := range tests {
+		_, err := types.Compile(tt.spec)
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+func Test_Compile_Serialize_Errors(t *testing.T) {
+	spec := appendSpec()
+	plan, err := types.Compile(spec)
+	assert.NoError(t, err)
+
+	for _, message := range []serdes.Value{
+		serdes.Map{"mti": "0100", "3": "abc"},
+		serdes.Map{"mti": "0100", "5": "000000"},
+		serdes.Map{"mti": "0100", "48": serdes.Map{"se": "invalid"}},
+		"invalid",
+	} {
+		_, expected := spec.Serialize(message)
+		_, err := plan.Serialize(message)
+		assert.Equal(t, expected, err)
+	}
+}
+
+func Test_Compile_Serialize_Errors_Masked(t *testing.T) {
+	spec := types.BitMapped{
+		Bitmap: types.Bitmap{BlockSize: 64, NumBits: 64},
+		Mapping: map[int]serdes.Serdes{
+			2:  types.VarLength{Length: types.Byte{}, Data: types.Bcd{Mask: types.MaskPAN}},
+			35: types.VarLength{Length: types.Byte{}, Data: types.Ascii{NumDigits: 4, Mask: types.MaskAll}},
+		},
+	}
+	plan, err := types.Compile(spec)
+	assert.NoError(t, err)
+
+	for _, message := range []serdes.Map{
+		{"2": "476173900101001X"},
+		{"35": "4761739001010010=2512"},
+	} {
+		_, expected := spec.Serialize(message)
+		_, err := plan.Serialize(message)
+		assert.Equal(t, expected, err)
+		assert.NotContains(t, err.Error(), "7390010")
+	}
+}
+
+func Test_Compile_Deserialize_Errors(t *testing.T) {
+	spec := appendSpec()
+	plan, err := types.Compile(spec)
+	assert.NoError(t, err)
+
+	data, err := spec.Serialize(appendMessage())
+	assert.NoError(t, err)
+
+	for _, size := range []int{1, 3, 11, 20, 40, data.Len() - 1} {
+		expectedValue, expectedErr := spec.Deserialize(bytes.NewBuffer(data.Bytes()[:size]))
+		value, err := plan.Deserialize(bytes.NewBuffer(data.Bytes()[:size]))
+		assert.Equal(t, expectedErr, err, size)
+		assert.Equal(t, expectedValue, value, size)
+	}
+}
+
+func Test_Compile_DeserializeInto(t *testing.T) {
+	plan, err := types.Compile(appendSpec())
+	assert.NoError(t, err)
+
+	data, err := plan.Serialize(appendMessage())
+	assert.NoError(t, err)
+
+	dst := serdes.Map{"stale": "value"}
+	assert.NoError(t, plan.DeserializeInto(data, dst))
+	assert.Equal(t, appendMessage(), dst)
+}
+
+func Test_Compile_Specs(t *testing.T) {
+	for _, spec := range []serdes.Serdes{
+		specs.ISO87ASCII(specs.HexBitmap),
+		specs.ISO93ASCII(specs.BinaryBitmap),
+		specs.ISO2003ASCII(specs.HexBitmap),
+		specs.VisaBaseI(),
+		specs.Mastercard(),
+		specs.AmexGCAG(),
+		specs.Discover(specs.HexBitmap),
+	} {
+		_, err := types.Compile(spec)
+		assert.NoError(t, err)
+	}
+}
+
+func Test_Plan_Offset(t *testing.T) {
+	plan, err := types.Compile(specs.VisaBaseI())
+	assert.NoError(t, err)
+
+	for path, expected := range map[string]int{
+		"header":                0,
+		"header.header_length":  0,
+		"header.message_length": 3,
+		"header.source_id":      8,
+		"header.user_info":      21,
+		"mti":                   22,
+	} {
+		offset, ok := plan.Offset(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, expected, offset, path)
+	}
+
+	_, ok := plan.Offset("2")
+	assert.False(t, ok)
+
+	_, fixed := plan.FixedSize()
+	assert.False(t, fixed)
+
+	header, err := types.Compile(types.List{Items: []types.Field{
+		{Name: "length", SerDes: types.Word{Order: binary.BigEndian}},
+		{Name: "pan", SerDes: types.VarLength{Length: types.Byte{}, Data: types.Bcd{}}},
+		{Name: "mti", SerDes: types.Bcd{NumDigits: 4}},
+	}})
+	assert.NoError(t, err)
+
+	size, fixed := header.FixedSize()
+	assert.False(t, fixed)
+	assert.Equal(t, 0, size)
+
+	offset, ok := header.Offset("pan")
+	assert.True(t, ok)
+	assert.Equal(t, 2, offset)
+
+	_, ok = header.Offset("mti")
+	assert.False(t, ok)
+}
+
+func Test_Plan_Spec_Changes(t *testing.T) {
+	spec := appendSpec()
+	plan, err := types.Compile(spec)
+	assert.NoError(t, err)
+
+	message := appendMessage()
+	expected, err := spec.Serialize(message)
+	assert.NoError(t, err)
+
+	spec.Items[0] = types.Field{Name: "mti", SerDes: types.Ascii{NumDigits: 4}}
+	bitMapped := spec.Items[1].SerDes.(types.BitMapped)
+	bitMapped.Mapping[3] = types.Ascii{NumDigits: 2}
+	se := bitMapped.Mapping[48].(types.VarLength).Data.(types.List).Items[1].SerDes.(types.TLV)
+	se.Items[0].SerDes = types.Raw{}
+
+	buffer, err := plan.Serialize(message)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Bytes(), buffer.Bytes())
+
+	size, err := plan.Size(message)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Len(), size)
+
+	value, err := plan.Deserialize(bytes.NewBuffer(expected.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, message, value)
+	assert.Equal(t, appendSpec(), plan.Spec())
+}
+
+func Test_Plan_Concurrent(t *testing.T) {
+	spec := appendSpec()
+	plan, err := types.Compile(spec)
+	assert.NoError(t, err)
+
+	expected, err := spec.Serialize(appendMessage())
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var dst []byte
+			for i := 0; i < 100; i++ {
+				var err error
+				if dst, err = plan.AppendSerialize(dst[:0], appendMessage()); err != nil {
+					errs <- err
+					return
+				}
+				if !bytes.Equal(expected.Bytes(), dst) {
+					errs <- assert.AnError
+					return
+				}
+				if _, err := plan.Deserialize(bytes.NewBuffer(dst)); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func Benchmark_Plan_AppendSerialize(b *testing.B) {
+	plan, _ := types.Compile(appendSpec())
+	message := appendMessage()
+	var dst []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err error
+		if dst, err = plan.AppendSerialize(dst[:0], message); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Plan_DeserializeInto(b *testing.B) {
+	plan, _ := types.Compile(appendSpec())
+	data, _ := plan.AppendSerialize(nil, appendMessage())
+	dst := serdes.Map{}
+	buffer := new(bytes.Buffer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buffer.Reset()
+		buffer.Write(data)
+		if err := plan.DeserializeInto(buffer, dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
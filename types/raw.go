@@ -9,6 +9,7 @@ import (
 
 type Raw struct {
 	Desc
+	Mask
 	NumBytes int
 }
 
@@ -17,23 +18,39 @@ func (raw Raw) Name() string {
 }
 
 func (raw Raw) Serialize(value serdes.Value) (*bytes.Buffer, error) {
-	rawValue, err := raw.normalizeData(value)
+	return serialize(raw, value)
+}
+
+// AppendSerialize appends the bytes of the hex string value.
+func (raw Raw) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	start := len(dst)
+	dst, err := raw.appendData(dst, value)
 	if err != nil {
-		return nil, err
+		return dst, err
 	}
 
 	numBytes := raw.NumBytes
 	if numBytes == 0 {
-		numBytes = len(rawValue)
+		numBytes = len(dst) - start
 	}
 
 	if raw.NumBytes > 0 && numBytes > raw.NumBytes {
-		return nil, SerializerError{
-			Message: "value too long", Serdes: raw, Value: value,
+		return dst[:start], SerializerError{
+			Message: "value too long", Serdes: raw, Value: raw.MaskValue(value), Err: ErrValueTooLong,
 		}
 	}
 
-	return bytes.NewBuffer(rawValue), nil
+	return dst, nil
+}
+
+// Size returns the number of bytes of the hex string, the value is not padded to NumBytes.
+func (raw Raw) Size(value serdes.Value) (int, error) {
+	valueStr, ok := value.(string)
+	if !ok || len(valueStr)%2 != 0 || !isHex(valueStr) {
+		_, err := raw.appendData(nil, value)
+		return 0, err
+	}
+	return len(valueStr) / 2, nil
 }
 
 func (raw Raw) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
@@ -45,7 +62,7 @@ func (raw Raw) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	if data.Len() < numBytes {
 		return nil, DeserializationError{
 			Message: "data does not has bytes enough", Serdes: raw, Remaning: data.Len(),
-		}
+		}.shortBuffer(numBytes, data.Len())
 	}
 
 	rawValue := data.Next(numBytes)
@@ -53,26 +70,68 @@ func (raw Raw) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	return valueHex, nil
 }
 
-func (raw Raw) normalizeData(value serdes.Value) ([]byte, error) {
+// appendData appends the bytes of the hex string value, dst is returned unchanged on error.
+func (raw Raw) appendData(dst []byte, value serdes.Value) ([]byte, error) {
 	valueStr, ok := value.(string)
 	if !ok {
-		return nil, SerializerError{
-			Message: "invalid value type", Serdes: raw, Value: value,
+		return dst, SerializerError{
+			Message: "invalid value type", Serdes: raw, Value: raw.MaskValue(value),
 		}
 	}
+	return raw.appendString(dst, valueStr)
+}
 
+// appendString appends the bytes of the hex string like appendData, without converting it to a serdes.Value.
+func (raw Raw) appendString(dst []byte, valueStr string) ([]byte, error) {
 	if len(valueStr)%2 != 0 {
-		return nil, SerializerError{
-			Message: "invalid value, hex string with odd num of char", Serdes: raw, Value: value,
+		return dst, SerializerError{
+			Message: "invalid value, hex string with odd num of char", Serdes: raw, Value: raw.MaskValue(valueStr),
 		}
 	}
 
-	rawValue, err := hex.DecodeString(valueStr)
-	if err != nil {
-		return nil, SerializerError{
-			Message: "invalid value, error decoding hex str", Serdes: raw, Value: value, Cause: err,
+	start := len(dst)
+	for index := 0; index < len(valueStr); index += 2 {
+		high, highOk := fromHexChar(valueStr[index])
+		low, lowOk := fromHexChar(valueStr[index+1])
+		if !highOk || !lowOk {
+			// hex reports the invalid character.
+			_, err := hex.DecodeString(valueStr)
+			return dst[:start], SerializerError{
+				Message: "invalid value, error decoding hex str", Serdes: raw, Value: raw.MaskValue(valueStr), Cause: err, Err: ErrInvalidCharacter,
+			}
+		}
+		dst = append(dst, high<<4|low)
+	}
+
+	return dst, nil
+}
+
+func (raw Raw) Children() []Child {
+	return nil
+}
+
+func (raw Raw) FixedSize() (int, bool) {
+	return raw.NumBytes, raw.NumBytes > 0
+}
+
+func isHex(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if _, ok := fromHexChar(value[i]); !ok {
+			return false
 		}
 	}
+	return true
+}
 
-	return rawValue, nil
+// fromHexChar returns the value of the hex character.
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
 }
@@ -0,0 +1,192 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+)
+
+// TagRange is the byte range of a tag of a TLV or BerTLV, the value of the tag is data[Value:End].
+type TagRange struct {
+	// Key is the key of the value of the tag in the decoded map.
+	Key string
+	// SerDes is the serdes that decodes the value of the tag, nil for the tags that are dropped.
+	SerDes serdes.Serdes
+	Start  int
+	Value  int
+	End    int
+}
+
+// Scan returns the size of the value of the serdes encoded at the start of data without decoding it, only the length
+// prefixes of the VarLength fields and the bitmaps of the BitMapped fields are decoded. The fields with a fixed size are
+// skipped and the other fields take the rest of the data, like their serdes do. The errors are DeserializationError
+// with the path and the offset of the field that failed, like the errors of Deserialize.
+func Scan(s serdes.Serdes, data []byte) (int, error) {
+	switch value := s.(type) {
+	case VarLength:
+		_, end, err := ScanVarLength(value, data)
+		return end, err
+	case List:
+		return scanList(value, data)
+	case BitMapped:
+		return scanBitMapped(value, data)
+	case Bitmap:
+		_, size, err := ScanBitmap(value, data)
+		return size, err
+	}
+
+	introspectable, ok := s.(Introspectable)
+	if !ok {
+		return len(data), nil
+	}
+
+	size, fixed := introspectable.FixedSize()
+	if !fixed {
+		return len(data), nil
+	}
+
+	if len(data) < size {
+		// the serdes reports why it cannot decode the data.
+		if _, err := s.Deserialize(bytes.NewBuffer(data)); err != nil {
+			return 0, err
+		}
+		return 0, DeserializationError{
+			Message: fmt.Sprintf("data has not %d bytes", size), Serdes: s, Remaning: len(data),
+		}.shortBuffer(size, len(data))
+	}
+	return size, nil
+}
+
+// ScanVarLength decodes the length prefix of the VarLength encoded at the start of data, it returns the offset of the
+// data and the end of the VarLength.
+func ScanVarLength(varLen VarLength, data []byte) (int, int, error) {
+	buffer := bytes.NewBuffer(data)
+	value, err := varLen.next(buffer)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end := len(data) - buffer.Len()
+	return end - value.Len(), end, nil
+}
+
+// ScanBitmap decodes the bitmap encoded at the start of data, it returns the numbers of the bits that are set and the
+// size of the bitmap.
+func ScanBitmap(bitmap Bitmap, data []byte) ([]int, int, error) {
+	if bitmap.BlockSize <= 0 || bitmap.BlockSize%8 != 0 {
+		return nil, 0, DeserializationError{
+			Message: fmt.Sprintf("invalid bitmap block size %d", bitmap.BlockSize), Serdes: bitmap,
+		}
+	}
+
+	buffer := bytes.NewBuffer(data)
+	value, err := bitmap.Deserialize(buffer)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bitmapBytes, _ := value.([]byte)
+	var bits []int
+	for bitIndex := 0; bitIndex < len(bitmapBytes)*8; bitIndex++ {
+		if bitmapBytes[bitIndex/8]&(0x80>>uint(bitIndex%8)) != 0 {
+			bits = append(bits, bitIndex+1)
+		}
+	}
+	return bits, len(data) - buffer.Len(), nil
+}
+
+// ScanTags splits the tags of the TLV or BerTLV encoded in data, the tags are read and decoded by the serdes that
+// Deserialize uses: the TLV decodes the tags that are not listed in its items as text and the BerTLV drops them.
+func ScanTags(s serdes.Serdes, data []byte) ([]TagRange, error) {
+	switch value := s.(type) {
+	case TLV:
+		tlvs, offsets, err := value.decode(data)
+		if err != nil {
+			return nil, tagsError(value, offsets, err)
+		}
+
+		tags := make([]TagRange, 0, len(tlvs))
+		for index, tlv := range tlvs {
+			key := value.tagKey(tlv.Tag)
+			tagSerdes := value.tagField(key).SerDes
+
+			end := offsets[index+1]
+			tags = append(tags, TagRange{
+				Key: key, SerDes: tagSerdes, Start: offsets[index], Value: end - len(tlv.Value), End: end,
+			})
+		}
+		return tags, nil
+	case BerTLV:
+		tlvs, offsets, err := decodeOffsets(value.SizeLen, data)
+		if err != nil {
+			return nil, tagsError(value, offsets, err)
+		}
+
+		tags := make([]TagRange, 0, len(tlvs))
+		for index, tlv := range tlvs {
+			key := hex.EncodeToString(encodeInt(tlv.Tag))
+			var tagSerdes serdes.Serdes
+			if field, err := value.findField(key); err == nil {
+				tagSerdes = field.SerDes
+			}
+
+			end := offsets[index+1]
+			tags = append(tags, TagRange{
+				Key: key, SerDes: tagSerdes, Start: offsets[index], Value: end - len(tlv.Value), End: end,
+			})
+		}
+		return tags, nil
+	}
+	return nil, DeserializationError{Message: "serdes has no tags", Serdes: s}
+}
+
+func scanList(list List, data []byte) (int, error) {
+	offset := 0
+	for _, field := range list.Items {
+		if offset == len(data) {
+			break
+		}
+
+		size, err := Scan(field.SerDes, data[offset:])
+		if err != nil {
+			return 0, DeserializationError{
+				Message: "field deserializer failed", Serdes: list, Field: field, Remaning: len(data) - offset, Cause: err,
+			}.within(field.Name, offset)
+		}
+		offset += size
+	}
+	return offset, nil
+}
+
+func scanBitMapped(bitMapped BitMapped, data []byte) (int, error) {
+	bits, offset, err := ScanBitmap(bitMapped.Bitmap, data)
+	if err != nil {
+		return 0, DeserializationError{
+			Message: "error decoding bitmap", Serdes: bitMapped, Remaning: len(data), Cause: err,
+		}.within("", 0)
+	}
+
+	for _, bitNumber := range bits {
+		bitKey := strconv.Itoa(bitNumber)
+		fieldSerdes := bitMapped.Mapping[bitNumber]
+		if fieldSerdes == nil {
+			return 0, DeserializationError{
+				Message: fmt.Sprintf("bit %d not found", bitNumber), Serdes: bitMapped, Remaning: len(data) - offset,
+				Err: ErrUnknownBit,
+			}.within(bitKey, offset)
+		}
+
+		size, err := Scan(fieldSerdes, data[offset:])
+		if err != nil {
+			return 0, DeserializationError{
+				Message: fmt.Sprintf("deserialize bit %d failed", bitNumber), Serdes: bitMapped,
+				Field: Field{Name: bitKey, SerDes: fieldSerdes}, Remaning: len(data) - offset, Cause: err,
+			}.within(bitKey, offset)
+		}
+		offset += size
+	}
+	return offset, nil
+}
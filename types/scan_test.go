@@ -0,0 +1,132 @@
+package types_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scanSpec() types.List {
+	return types.List{Items: []types.Field{
+		{Name: "mti", SerDes: types.AsciiNumeric{NumDigits: 4}},
+		{SerDes: types.BitMapped{
+			Bitmap: types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true},
+			Mapping: map[int]serdes.Serdes{
+				2: types.VarLength{Length: types.Byte{}, Data: types.Bcd{}},
+				3: types.AsciiNumeric{NumDigits: 6},
+				48: types.VarLength{Length: types.AsciiNumeric{NumDigits: 3}, Data: types.TLV{SizeTag: 2, SizeLen: 3, Items: []types.Field{
+					{Name: "21", SerDes: types.Ebcdic{}},
+				}}},
+				55: types.VarLength{Length: types.Word{Order: binary.BigEndian}, Data: types.BerTLV{Items: []types.Field{
+					{Name: "9f36", SerDes: types.Raw{}},
+				}}},
+				70: types.AsciiNumeric{NumDigits: 3},
+			},
+		}},
+		{Name: "trailer", SerDes: types.Ascii{}},
+	}}
+}
+
+func Test_Scan(t *testing.T) {
+	message := serdes.Map{
+		"mti":     "0100",
+		"2":       "4761739001010010",
+		"3":       "000000",
+		"48":      serdes.Map{"21": "01010"},
+		"55":      serdes.Map{"9f36": "0001"},
+		"70":      "301",
+		"trailer": "END",
+	}
+	spec := scanSpec()
+	data, err := spec.Serialize(message)
+	assert.NoError(t, err)
+
+	size, err := types.Scan(spec, data.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, data.Len(), size)
+
+	// the trailer has no size, it takes the rest of the data.
+	size, err = types.Scan(spec, append(data.Bytes(), "MORE"...))
+	assert.NoError(t, err)
+	assert.Equal(t, data.Len()+4, size)
+
+	// mti(4) bitmap(32) 2: length(1) and 8 bytes of digits.
+	dataStart, end, err := types.ScanVarLength(types.VarLength{Length: types.Byte{}, Data: types.Bcd{}}, data.Bytes()[36:])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dataStart)
+	assert.Equal(t, 9, end)
+
+	bits, bitmapSize, err := types.ScanBitmap(types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true}, data.Bytes()[4:])
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 48, 55, 70}, bits)
+	assert.Equal(t, 32, bitmapSize)
+}
+
+func Test_ScanTags(t *testing.T) {
+	tlv := types.TLV{SizeTag: 2, SizeLen: 3, Items: []types.Field{
+		{Name: "21", SerDes: types.Ebcdic{}},
+		{Name: "99", SerDes: types.Ebcdic{NumDigits: 1}},
+	}}
+	data, err := tlv.Serialize(serdes.Map{"21": "01010", "99": "X"})
+	assert.NoError(t, err)
+
+	tags, err := types.ScanTags(tlv, data.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []types.TagRange{
+		{Key: "21", SerDes: types.Ebcdic{}, Start: 0, Value: 5, End: 10},
+		{Key: "99", SerDes: types.Ebcdic{NumDigits: 1}, Start: 10, Value: 15, End: 16},
+	}, tags)
+
+	berTLV := types.BerTLV{Items: []types.Field{{Name: "9f36", SerDes: types.Raw{}}, {Name: "5a", SerDes: types.Raw{}}}}
+	data, err = berTLV.Serialize(serdes.Map{"9f36": "0001", "5a": "ff"})
+	assert.NoError(t, err)
+
+	// the BerTLV drops the tags that are not listed.
+	berTLV.Items = berTLV.Items[:1]
+	assert.NoError(t, err)
+
+	tags, err = types.ScanTags(berTLV, data.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []types.TagRange{
+		{Key: "9f36", SerDes: types.Raw{}, Start: 0, Value: 3, End: 5},
+		{Key: "5a", Start: 5, Value: 7, End: 8},
+	}, tags)
+
+	_, err = types.ScanTags(tlv, data.Bytes()[:4])
+	assert.Error(t, err)
+
+	_, err = types.ScanTags(types.Ascii{}, data.Bytes())
+	assert.EqualError(t, err, "serdes has no tags: deserializer: ascii.")
+}
+
+func Test_Scan_Errors(t *testing.T) {
+	spec := scanSpec()
+	data, err := spec.Serialize(serdes.Map{"mti": "0100", "3": "000000", "70": "301"})
+	assert.NoError(t, err)
+
+	_, err = types.Scan(spec, data.Bytes()[:data.Len()-1])
+	var deserializationErr types.DeserializationError
+	assert.True(t, errors.As(err, &deserializationErr))
+	assert.True(t, errors.Is(err, types.ErrShortBuffer))
+	assert.Equal(t, "70", deserializationErr.Path)
+	assert.Equal(t, 42, deserializationErr.Offset)
+
+	_, err = spec.Deserialize(bytes.NewBuffer(data.Bytes()[:data.Len()-1]))
+	assert.True(t, errors.As(err, &deserializationErr))
+	assert.Equal(t, "70", deserializationErr.Path)
+	assert.Equal(t, 42, deserializationErr.Offset)
+
+	withoutField := scanSpec()
+	delete(withoutField.Items[1].SerDes.(types.BitMapped).Mapping, 3)
+	_, err = types.Scan(withoutField, data.Bytes())
+	assert.True(t, errors.Is(err, types.ErrUnknownBit))
+
+	_, _, err = types.ScanBitmap(types.Bitmap{NumBits: 64}, data.Bytes())
+	assert.EqualError(t, err, "invalid bitmap block size 0: deserializer: bitmap.")
+}
@@ -0,0 +1,96 @@
+package types_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/mercadolibre/go-iso8583/serdes"
+	"github.com/mercadolibre/go-iso8583/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Size(t *testing.T) {
+	tests := []struct {
+		serdes serdes.Serdes
+		value  serdes.Value
+	}{
+		{types.Ascii{NumDigits: 5}, "ab"},
+		{types.Ascii{NumDigits: 5}, "añ"},
+		{types.Ascii{}, "añ"},
+		{types.AsciiNumeric{NumDigits: 5}, "12"},
+		{types.EbcdicNumeric{}, "ñ"},
+		{types.Ebcdic{NumDigits: 5}, "ab"},
+		{types.Ebcdic{}, "añ"},
+		{types.EbcdicNumeric{NumDigits: 5}, "12"},
+		{types.Bcd{NumDigits: 5}, "123"},
+		{types.Bcd{}, "12345"},
+		{types.Bcd{NotPadded: true}, "1234D"},
+		{types.Raw{}, "0a0b"},
+		{types.Raw{NumBytes: 4}, "0a0b"},
+		{types.Byte{}, "7"},
+		{types.Word{Order: binary.LittleEndian}, "513"},
+		{types.Bitmap{BlockSize: 64, NumBits: 128, Hex: true}, []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0x80}},
+		{types.Bitmap{BlockSize: 64, NumBits: 128}, []byte{0x01}},
+		{types.VarLength{Length: types.AsciiNumeric{NumDigits: 2}, Data: types.Ascii{}}, "hello"},
+		{types.VarLength{Length: types.Byte{}, Data: types.Bcd{}}, "476173900101001"},
+		{types.BerTLV{SizeLen: 2, Items: []types.Field{{Name: "9f26", SerDes: types.Raw{}}}}, serdes.Map{"9f26": "0102"}},
+		{types.BerTLV{Items: []types.Field{{Name: "5a", SerDes: types.Raw{}}}}, serdes.Map{"5a": hexOf(200)}},
+		{appendSpec(), appendMessage()},
+		{appendSpec(), serdes.Map{"mti": "0800", "70": "301"}},
+		{appendSpec(), serdes.Map{}},
+	}
+
+	for _, tt := range tests {
+		data, err := tt.serdes.Serialize(tt.value)
+		assert.NoError(t, err, tt.serdes.Name())
+
+		size, err := serdes.Size(tt.serdes, tt.value)
+		assert.NoError(t, err, tt.serdes.Name())
+		assert.Equal(t, data.Len(), size, tt.serdes.Name())
+	}
+}
+
+func Test_Size_Errors(t *testing.T) {
+	tests := []struct {
+		serdes serdes.Serdes
+		value  serdes.Value
+	}{
+		{types.Ascii{NumDigits: 1}, "ab"},
+		{types.AsciiNumeric{}, 1},
+		{types.Ebcdic{}, "€"},
+		{types.EbcdicNumeric{NumDigits: 1}, "12"},
+		{types.Bcd{}, "12a"},
+		{types.Bcd{NumDigits: 2}, "123"},
+		{types.Raw{}, "0a0"},
+		{types.Raw{}, "zz"},
+		{types.Byte{}, "a"},
+		{types.Word{}, "1"},
+		{types.Bitmap{BlockSize: 64, NumBits: 64}, "1"},
+		{types.VarLength{Length: types.AsciiNumeric{NumDigits: 1}, Data: types.Ascii{}}, "0123456789"},
+		{types.TLV{Items: []types.Field{{SerDes: types.Ascii{}}}}, serdes.Map{}},
+		{types.BerTLV{Items: []types.Field{{Name: "9f", SerDes: types.Raw{}}}}, serdes.Map{"9f": "01"}},
+		{appendSpec(), serdes.Map{"mti": "0100", "5": "000000"}},
+		{appendSpec(), serdes.Map{"mti": "0100", "48": serdes.Map{"se": "invalid"}}},
+		{appendSpec(), "invalid"},
+	}
+
+	for _, tt := range tests {
+		_, expected := tt.serdes.Serialize(tt.value)
+		_, err := serdes.Size(tt.serdes, tt.value)
+		assert.Error(t, err, tt.serdes.Name())
+		assert.Equal(t, expected, err, tt.serdes.Name())
+	}
+
+	// the value of the error is the value of the tag, the encoded value is not available.
+	_, err := types.TLV{Items: []types.Field{{Name: "21", SerDes: types.Ascii{}}}}.Size(serdes.Map{"21": hexOf(50)})
+	assert.EqualError(t, err, "length serializer failed: serializer: tlv. value type: string.")
+}
+
+func hexOf(numBytes int) string {
+	out := make([]byte, numBytes*2)
+	for i := range out {
+		out[i] = '0'
+	}
+	return string(out)
+}
@@ -20,8 +20,9 @@ type TagValueMas struct {
 
 type TLV struct {
 	Desc
-	SizeLen int // size of length
-	SizeTag int // size of tag
+	SizeLen int  // size of length
+	SizeTag int  // size of tag
+	Ascii   bool // tags and lengths in ASCII digits, by default they are EBCDIC digits
 	Items   []Field
 }
 
@@ -35,19 +36,26 @@ func (t TLV) Name() string {
 }
 
 func (t TLV) Serialize(data serdes.Value) (*bytes.Buffer, error) {
+	return serialize(t, data)
+}
+
+// AppendSerialize appends the listed items as tag, length and value, the length is written once the value is encoded.
+func (t TLV) AppendSerialize(dst []byte, data serdes.Value) ([]byte, error) {
 	mapValue, ok := data.(serdes.Map)
 	if !ok {
-		return nil, SerializerError{
-			Message: fmt.Sprintf("invalid value [%T], expected: %T", data, serdes.Map{}), Value: data, Serdes: t,
+		return dst, SerializerError{
+			Message: fmt.Sprintf("invalid value [%T], expected: %T", data, serdes.Map{}), Value: maskValue(t, data), Serdes: t,
 		}
 	}
 
-	serializedData := new(bytes.Buffer)
+	sizeTag, sizeLen := t.sizes()
+
+	start := len(dst)
 	for _, field := range t.Items {
 		if field.Name == "" {
-			return nil, SerializerError{
+			return dst[:start], SerializerError{
 				Message: "field name not found", Serdes: t, Field: field,
-			}
+			}.within(field.Name)
 		}
 
 		itemValue, ok := mapValue[field.Name]
@@ -55,105 +63,185 @@ func (t TLV) Serialize(data serdes.Value) (*bytes.Buffer, error) {
 			continue
 		}
 
-		data, err := field.SerDes.Serialize(itemValue)
+		encoded, err := t.appendTag(dst, sizeTag, field.Name)
+		if err != nil {
+			return dst[:start], SerializerError{
+				Message: "tag serializer failed", Serdes: t, Field: field, Cause: err,
+			}.within(field.Name)
+		}
+
+		lenStart := len(encoded)
+		encoded = grow(encoded, sizeLen)
+		encoded, err = serdes.AppendSerialize(encoded, field.SerDes, itemValue)
 		if err != nil {
-			return nil, SerializerError{
+			return dst[:start], SerializerError{
 				Message: "value serializer failed", Serdes: t, Field: field, Cause: err,
-			}
+			}.within(field.Name)
 		}
 
-		sizeTag := t.SizeTag
-		if sizeTag == 0 {
-			sizeTag = _defaultSizeTag
+		// check capacity
+		valueLen := len(encoded) - lenStart - sizeLen
+		if valueLen >= intPow(10, sizeLen) {
+			return dst[:start], SerializerError{
+				Message: "length serializer failed", Value: maskValue(field.SerDes, bytes.NewBuffer(encoded[lenStart+sizeLen:])), Serdes: t, Err: ErrValueTooLong,
+			}.within(field.Name)
 		}
-		tag, err := EbcdicNumeric{NumDigits: sizeTag}.Serialize(field.Name)
-		if err != nil {
-			return nil, SerializerError{
-				Message: "tag serializer failed", Serdes: t, Field: field, Cause: err,
-			}
+
+		putLenMas(encoded[lenStart:lenStart+sizeLen], valueLen, t.zone())
+		dst = encoded
+	}
+
+	return dst, nil
+}
+
+// Size returns the size of the tag, length and value of the listed items.
+func (t TLV) Size(data serdes.Value) (int, error) {
+	mapValue, ok := data.(serdes.Map)
+	if !ok {
+		return 0, SerializerError{
+			Message: fmt.Sprintf("invalid value [%T], expected: %T", data, serdes.Map{}), Value: maskValue(t, data), Serdes: t,
 		}
+	}
+
+	sizeTag, sizeLen := t.sizes()
 
-		sizeLen := t.SizeLen
-		if sizeLen == 0 {
-			sizeLen = _defaultSizeLen
+	size := 0
+	for _, field := range t.Items {
+		if field.Name == "" {
+			return 0, SerializerError{
+				Message: "field name not found", Serdes: t, Field: field,
+			}.within(field.Name)
 		}
 
-		builtTlv := TagValueMas{
-			Tag:     tag.Bytes(),
-			Value:   data.Bytes(),
-			SizeLen: sizeLen,
+		itemValue, ok := mapValue[field.Name]
+		if !ok {
+			continue
 		}
 
-		// check capacity
-		if data.Len() >= intPow(10, sizeLen) {
-			return nil, SerializerError{
-				Message: "length serializer failed", Value: data, Serdes: t,
-			}
+		tagSize, err := t.tagSize(sizeTag, field.Name)
+		if err != nil {
+			return 0, SerializerError{
+				Message: "tag serializer failed", Serdes: t, Field: field, Cause: err,
+			}.within(field.Name)
+		}
+
+		valueSize, err := serdes.Size(field.SerDes, itemValue)
+		if err != nil {
+			return 0, SerializerError{
+				Message: "value serializer failed", Serdes: t, Field: field, Cause: err,
+			}.within(field.Name)
 		}
 
-		if _, err := serializedData.ReadFrom(builtTlv.bufferBytes()); err != nil {
-			return nil, SerializerError{
-				Message: "data struct serializer failed", Value: data, Serdes: t, Cause: err,
-			}
+		if valueSize >= intPow(10, sizeLen) {
+			return 0, SerializerError{
+				Message: "length serializer failed", Value: maskValue(field.SerDes, itemValue), Serdes: t, Err: ErrValueTooLong,
+			}.within(field.Name)
 		}
+		size += tagSize + sizeLen + valueSize
 	}
 
-	return serializedData, nil
+	return size, nil
 }
 
 func (t TLV) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	listValues := serdes.Map{}
+	if err := t.deserializeInto(data, listValues); err != nil {
+		return nil, err
+	}
+	return listValues, nil
+}
 
+// DeserializeInto clears dst and decodes the tags into it.
+func (t TLV) DeserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	clearMap(dst)
+	return t.deserializeInto(data, dst)
+}
+
+func (t TLV) deserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	mapTLV, offsets, err := t.decode(data.Next(data.Len()))
+	if err != nil {
+		return tagsError(t, offsets, err)
+	}
+
+	for index, tlv := range mapTLV {
+		tagValue := t.tagKey(tlv.Tag)
+		field := t.tagField(tagValue)
+
+		value, err := field.SerDes.Deserialize(bytes.NewBuffer(tlv.Value))
+		if err != nil {
+			return DeserializationError{
+				Message: "struct data deserializer failed", Serdes: t, Field: field, Cause: err,
+			}.within(tagValue, offsets[index+1]-len(tlv.Value))
+		}
+
+		dst[tagValue] = value
+	}
+
+	return nil
+}
+
+// sizes returns the size of the tags and the size of the lengths, the sizes that are not set have their default.
+func (t TLV) sizes() (int, int) {
 	sizeTag := t.SizeTag
 	if sizeTag == 0 {
 		sizeTag = _defaultSizeTag
 	}
 
-	sizeLen := t.SizeTag
+	sizeLen := t.SizeLen
 	if sizeLen == 0 {
 		sizeLen = _defaultSizeLen
 	}
+	return sizeTag, sizeLen
+}
 
-	mapTLV, err := decodeMas(sizeTag, sizeLen, data.Bytes())
-	if err != nil {
-		return nil, DeserializationError{
-			Message: "data struct deserializer failed", Serdes: t, Remaning: data.Len(), Cause: err,
-		}
-	}
+// decode splits the encoded tags, see decodeMas.
+func (t TLV) decode(p []byte) ([]TagValueMas, []int, error) {
+	sizeTag, sizeLen := t.sizes()
+	return decodeMas(sizeTag, sizeLen, p)
+}
 
-	for _, tlv := range mapTLV {
-		tag, err := EbcdicNumeric{}.Deserialize(bytes.NewBuffer(tlv.Tag))
-		if err != nil {
-			return nil, DeserializationError{
-				Message: "tag deserializer failed", Serdes: t, Cause: err,
-			}
-		}
+// appendTag appends the tag right justified and padded with zeros.
+func (t TLV) appendTag(dst []byte, sizeTag int, tag string) ([]byte, error) {
+	if t.Ascii {
+		return AsciiNumeric{NumDigits: sizeTag}.appendString(dst, tag)
+	}
+	return EbcdicNumeric{NumDigits: sizeTag}.appendString(dst, tag)
+}
 
-		tagValue, ok := tag.(string)
-		if !ok {
-			return nil, DeserializationError{
-				Message: "tag type is not string", Serdes: t,
-			}
-		}
+// tagSize returns the size of the encoded tag.
+func (t TLV) tagSize(sizeTag int, tag string) (int, error) {
+	if t.Ascii {
+		return AsciiNumeric{NumDigits: sizeTag}.Size(tag)
+	}
+	return EbcdicNumeric{NumDigits: sizeTag}.Size(tag)
+}
 
-		field, err := t.findField(tagValue)
-		if err != nil {
-			//continue
-			// default serializer
-			field = Field{Name: tagValue, SerDes: Ebcdic{}}
-		}
+// tagKey returns the key of the value of the encoded tag.
+func (t TLV) tagKey(tag []byte) string {
+	if t.Ascii {
+		return string(tag)
+	}
+	return decodeEbcdic(tag)
+}
 
-		value, err := field.SerDes.Deserialize(bytes.NewBuffer(tlv.Value))
-		if err != nil {
-			return nil, DeserializationError{
-				Message: "struct data deserializer failed", Serdes: t, Field: field, Cause: err,
-			}
-		}
+// tagField returns the item of the tag, the tags that are not listed are decoded as text of the TLV encoding.
+func (t TLV) tagField(tag string) Field {
+	if field, err := t.findField(tag); err == nil {
+		return field
+	}
 
-		listValues[tagValue] = value
+	if t.Ascii {
+		return Field{Name: tag, SerDes: Ascii{}}
 	}
+	return Field{Name: tag, SerDes: Ebcdic{}}
+}
 
-	return listValues, nil
+// zone returns the zone bits of the digits of the lengths.
+func (t TLV) zone() byte {
+	if t.Ascii {
+		return 0x30
+	}
+	return 0xf0
 }
 
 func (t TLV) findField(tag string) (Field, error) {
@@ -183,23 +271,21 @@ func (tv TagValueMas) tag() []byte {
 
 // len returns encoded length of the value.
 func (tv TagValueMas) len() []byte {
-	l := len(tv.Value)
-	size := tv.SizeLen
-	b := make([]byte, size)
+	b := make([]byte, tv.SizeLen)
+	putLenMas(b, len(tv.Value), 0xf0)
+	return b
+}
 
+// putLenMas encodes the length l in the digits of b, the zone is 0xf0 for EBCDIC digits and 0x30 for ASCII digits.
+func putLenMas(b []byte, l int, zone byte) {
+	size := len(b)
 	for i := 0; i < size-1; i++ {
 		y := size - 1 - i
 		exp := intPow(10, y)
-		b[i] = byte(l / exp)
+		b[i] = byte(l/exp) | zone
 		l = l % exp
 	}
-	b[size-1] = byte(l)
-
-	for i := range b {
-		b[i] |= 0xf0
-	}
-
-	return b
+	b[size-1] = byte(l) | zone
 }
 
 func (tv *TagValueMas) readFrom(r io.Reader) (n int64, err error) {
@@ -267,11 +353,13 @@ func readLenMas(size int, r io.Reader) (length int, n int, err error) {
 	return l, n, nil
 }
 
-// decode decodes TLV encoded byte slice into slice of TagValueMas structs.
-func decodeMas(sizeTag int, sizeLen int, p []byte) ([]TagValueMas, error) {
+// decodeMas decodes TLV encoded byte slice into slice of TagValueMas structs, it also returns the offsets of the tags in
+// p followed by the offset of their end, which is the offset of the tag that could not be decoded on errors.
+func decodeMas(sizeTag int, sizeLen int, p []byte) ([]TagValueMas, []int, error) {
 	r := bytes.NewReader(p)
 
 	var result []TagValueMas
+	offsets := []int{0}
 	for {
 		tv := TagValueMas{SizeTag: sizeTag, SizeLen: sizeLen}
 		_, err := tv.readFrom(r)
@@ -280,13 +368,14 @@ func decodeMas(sizeTag int, sizeLen int, p []byte) ([]TagValueMas, error) {
 		}
 
 		if err != nil {
-			return nil, err
+			return nil, offsets, err
 		}
 
 		result = append(result, tv)
+		offsets = append(offsets, len(p)-r.Len())
 	}
 
-	return result, nil
+	return result, offsets, nil
 }
 
 // intPow calculates x to the yth power
@@ -300,3 +389,11 @@ func intPow(x, y int) int {
 	}
 	return result
 }
+
+func (t TLV) Children() []Child {
+	return fieldChildren(t.Items)
+}
+
+func (t TLV) FixedSize() (int, bool) {
+	return 0, false
+}
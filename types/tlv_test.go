@@ -158,6 +158,19 @@ func TestTLV_SerializeDeserialize(t *testing.T) {
 			want:    bytes.NewBuffer([]byte{0xf0, 0xf0, 0xf1, 0xf0, 0xf0, 0xf4, 0xf2, 0xf0, 0xf0, 0xf1}),
 			wantErr: false,
 		},
+		{
+			name: "serialize and deserialize with size tag = 2 and size len = 3",
+			taipe: types.TLV{
+				SizeTag: 2, SizeLen: 3,
+				Items: []types.Field{
+					{Name: "21", SerDes: types.Ebcdic{}},
+					{Name: "61", SerDes: types.Ebcdic{}},
+				},
+			},
+			data:    map[string]interface{}{"21": "AB", "61": "C"},
+			want:    bytes.NewBuffer([]byte{0xf2, 0xf1, 0xf0, 0xf0, 0xf2, 0xc1, 0xc2, 0xf6, 0xf1, 0xf0, 0xf0, 0xf1, 0xc3}),
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -311,3 +324,90 @@ func TestTLV_ErrorDeserialize(t *testing.T) {
 		})
 	}
 }
+
+func TestTLV_Deserialize_Consumes_Buffer(t *testing.T) {
+	data := bytes.NewBuffer([]byte{0xf2, 0xf1, 0xf0, 0xf2, 0xc1, 0xc2})
+	value, err := types.TLV{Items: []types.Field{{Name: "21", SerDes: types.Ebcdic{}}}}.Deserialize(data)
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{"21": "AB"}, value)
+	assert.Equal(t, 0, data.Len())
+}
+
+// The lengths of the tags are decoded with SizeLen digits, like they are encoded. They used to be decoded with SizeTag
+// digits, which only differs when SizeTag is set and SizeLen is not the same.
+func TestTLV_Deserialize_Size_Len(t *testing.T) {
+	items := []types.Field{{Name: "21", SerDes: types.Ebcdic{}}}
+	tests := []struct {
+		name string
+		tlv  types.TLV
+		data []byte
+	}{
+		{
+			name: "default sizes, unchanged",
+			tlv:  types.TLV{Items: items},
+			data: []byte{0xf2, 0xf1, 0xf0, 0xf2, 0xc1, 0xc2},
+		},
+		{
+			name: "same size tag and size len, unchanged",
+			tlv:  types.TLV{SizeTag: 2, SizeLen: 2, Items: items},
+			data: []byte{0xf2, 0xf1, 0xf0, 0xf2, 0xc1, 0xc2},
+		},
+		{
+			name: "size len longer than size tag, used to read 2 digits",
+			tlv:  types.TLV{SizeTag: 2, SizeLen: 3, Items: items},
+			data: []byte{0xf2, 0xf1, 0xf0, 0xf0, 0xf2, 0xc1, 0xc2},
+		},
+		{
+			name: "size tag without size len, used to read 4 digits",
+			tlv:  types.TLV{SizeTag: 4, Items: []types.Field{{Name: "0021", SerDes: types.Ebcdic{}}}},
+			data: []byte{0xf0, 0xf0, 0xf2, 0xf1, 0xf0, 0xf2, 0xc1, 0xc2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := serdes.Map{tt.tlv.Items[0].Name: "AB"}
+
+			buffer, err := tt.tlv.Serialize(expected)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.data, buffer.Bytes())
+
+			value, err := tt.tlv.Deserialize(bytes.NewBuffer(tt.data))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value)
+
+			plan, err := types.Compile(tt.tlv)
+			assert.NoError(t, err)
+			value, err = plan.Deserialize(bytes.NewBuffer(tt.data))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value)
+
+			value, errs := types.DeserializePartial(tt.tlv, bytes.NewBuffer(tt.data))
+			assert.Empty(t, errs)
+			assert.Equal(t, expected, value)
+		})
+	}
+}
+
+func TestTLV_Ascii(t *testing.T) {
+	tlv := types.TLV{SizeTag: 2, SizeLen: 3, Ascii: true, Items: []types.Field{{Name: "01", SerDes: types.Ascii{}}}}
+	data := "01002AB" + "99003XYZ"
+
+	value, err := tlv.Deserialize(bytes.NewBufferString(data))
+	assert.NoError(t, err)
+	assert.Equal(t, serdes.Map{"01": "AB", "99": "XYZ"}, value)
+
+	buffer, err := tlv.Serialize(serdes.Map{"01": "AB"})
+	assert.NoError(t, err)
+	assert.Equal(t, "01002AB", buffer.String())
+
+	size, err := serdes.Size(tlv, serdes.Map{"01": "AB"})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, size)
+
+	tags, err := types.ScanTags(tlv, []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []types.TagRange{
+		{Key: "01", SerDes: types.Ascii{}, Start: 0, Value: 5, End: 7},
+		{Key: "99", SerDes: types.Ascii{}, Start: 7, Value: 12, End: 15},
+	}, tags)
+}
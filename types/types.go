@@ -10,3 +10,12 @@ type Field struct {
 }
 
 type Desc string
+
+// Described is implemented by every type that embeds Desc.
+type Described interface {
+	Description() string
+}
+
+func (desc Desc) Description() string {
+	return string(desc)
+}
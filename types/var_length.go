@@ -18,15 +18,29 @@ func (varLen VarLength) Name() string {
 }
 
 func (varLen VarLength) Serialize(value serdes.Value) (*bytes.Buffer, error) {
-	serializedData, err := varLen.Data.Serialize(value)
+	return serialize(varLen, value)
+}
+
+// AppendSerialize appends the data and then its length, which is moved before the data.
+func (varLen VarLength) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
+	_, bcdData := varLen.Data.(Bcd)
+	return varLen.appendWith(dst, value, varLen.Length, varLen.Data, bcdData)
+}
+
+// appendWith encodes the value like AppendSerialize with the serdes of the length and the data, which encode the
+// values of the serdes of the VarLength.
+func (varLen VarLength) appendWith(dst []byte, value serdes.Value, length, data serdes.Serializer, bcdData bool) ([]byte, error) {
+	start := len(dst)
+	encoded, err := serdes.AppendSerialize(dst, data, value)
 	if err != nil {
-		return nil, SerializerError{
-			Message: "error serializing data", Serdes: varLen, Value: value, Cause: err,
-		}
+		return dst, SerializerError{
+			Message: "error serializing data", Serdes: varLen, Value: maskValue(varLen, value), Cause: err,
+		}.within("")
 	}
+	dst = encoded
 
-	deserializedLength := serializedData.Len()
-	if _, ok := varLen.Data.(Bcd); ok {
+	deserializedLength := len(dst) - start
+	if bcdData {
 		deserializedLength = deserializedLength * 2
 		// Visa specify (pag. 76) that BCD types must indicate real data size, ignoring leading zeros.
 		if strType, ok := value.(string); ok {
@@ -34,24 +48,135 @@ func (varLen VarLength) Serialize(value serdes.Value) (*bytes.Buffer, error) {
 		}
 	}
 
-	deserializedLengthStr := strconv.Itoa(deserializedLength)
-	serializedLength, err := varLen.Length.Serialize(deserializedLengthStr)
+	return varLen.appendLength(dst, start, length, deserializedLength, value)
+}
+
+// AppendEncoded appends the data already encoded by the serdes of the data and its length prefix. The length is
+// counted like AppendSerialize counts it for the values that are not strings, in digits for Bcd data.
+func (varLen VarLength) AppendEncoded(dst []byte, data []byte) ([]byte, error) {
+	start := len(dst)
+	dst = append(dst, data...)
+
+	deserializedLength := len(data)
+	if _, bcdData := varLen.Data.(Bcd); bcdData {
+		deserializedLength = deserializedLength * 2
+	}
+	return varLen.appendLength(dst, start, varLen.Length, deserializedLength, nil)
+}
+
+// appendLength appends the length of the data that starts at start and moves it before the data, the value is the
+// decoded data reported in the errors.
+func (varLen VarLength) appendLength(dst []byte, start int, length serdes.Serializer, size int, value serdes.Value) ([]byte, error) {
+	dataEnd := len(dst)
+	encoded, err := serdes.AppendSerialize(dst, length, decimal(size))
 	if err != nil {
-		return nil, SerializerError{
-			Message: "error serializing length", Serdes: varLen, Value: value, Cause: err,
+		return dst[:start], SerializerError{
+			Message: "error serializing length", Serdes: varLen, Value: maskValue(varLen, value), Cause: err,
+		}.within("")
+	}
+
+	moveBefore(encoded, start, dataEnd)
+	return encoded, nil
+}
+
+// Size returns the size of the length prefix and the data.
+func (varLen VarLength) Size(value serdes.Value) (int, error) {
+	dataSize, err := serdes.Size(varLen.Data, value)
+	if err != nil {
+		return 0, SerializerError{
+			Message: "error serializing data", Serdes: varLen, Value: maskValue(varLen, value), Cause: err,
+		}.within("")
+	}
+
+	deserializedLength := dataSize
+	if _, ok := varLen.Data.(Bcd); ok {
+		deserializedLength = deserializedLength * 2
+		if strType, ok := value.(string); ok {
+			deserializedLength = len(strType)
 		}
 	}
 
-	out := append(serializedLength.Bytes(), serializedData.Bytes()...)
-	return bytes.NewBuffer(out), nil
+	lengthSize, err := serdes.Size(varLen.Length, decimal(deserializedLength))
+	if err != nil {
+		return 0, SerializerError{
+			Message: "error serializing length", Serdes: varLen, Value: maskValue(varLen, value), Cause: err,
+		}.within("")
+	}
+	return lengthSize + dataSize, nil
 }
 
 func (varLen VarLength) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
-	deserializedLength, err := varLen.Length.Deserialize(data)
+	_, bcdData := varLen.Data.(Bcd)
+	return varLen.deserializeWith(data, varLen.Length, varLen.Data, bcdData)
+}
+
+// deserializeWith decodes the value like Deserialize with the serdes of the length and the data, which decode the
+// values of the serdes of the VarLength.
+func (varLen VarLength) deserializeWith(data *bytes.Buffer, length, value serdes.Deserialize, bcdData bool) (serdes.Value, error) {
+	size := data.Len()
+	serializedData, err := varLen.nextWith(data, length, bcdData)
+	if err != nil {
+		return nil, err
+	}
+
+	if serializedData.Len() == 0 {
+		return serializedData, nil
+	}
+
+	offset := size - data.Len() - serializedData.Len()
+	deserializedData, err := value.Deserialize(serializedData)
+	if err != nil {
+		return nil, DeserializationError{
+			Message: "deserializer failed", Serdes: varLen, Remaning: data.Len(), Cause: err,
+		}.within("", offset)
+	}
+
+	return deserializedData, nil
+}
+
+// DeserializeInto clears dst and decodes the map of the data into it.
+func (varLen VarLength) DeserializeInto(data *bytes.Buffer, dst serdes.Map) error {
+	_, bcdData := varLen.Data.(Bcd)
+	return varLen.deserializeIntoWith(data, dst, varLen.Length, varLen.Data, bcdData)
+}
+
+// deserializeIntoWith decodes the map like DeserializeInto with the serdes of the length and the data.
+func (varLen VarLength) deserializeIntoWith(data *bytes.Buffer, dst serdes.Map, length, value serdes.Deserialize, bcdData bool) error {
+	size := data.Len()
+	serializedData, err := varLen.nextWith(data, length, bcdData)
+	if err != nil {
+		return err
+	}
+
+	if serializedData.Len() == 0 {
+		clearMap(dst)
+		return nil
+	}
+
+	offset := size - data.Len() - serializedData.Len()
+	if err := serdes.DeserializeInto(serializedData, value, dst); err != nil {
+		return DeserializationError{
+			Message: "deserializer failed", Serdes: varLen, Remaning: data.Len(), Cause: err,
+		}.within("", offset)
+	}
+	return nil
+}
+
+// next decodes the length and returns the data.
+func (varLen VarLength) next(data *bytes.Buffer) (*bytes.Buffer, error) {
+	_, bcdData := varLen.Data.(Bcd)
+	return varLen.nextWith(data, varLen.Length, bcdData)
+}
+
+// nextWith decodes the length with the serdes of the length and returns the data, the length of Bcd data is counted in
+// digits.
+func (varLen VarLength) nextWith(data *bytes.Buffer, length serdes.Deserialize, bcdData bool) (*bytes.Buffer, error) {
+	size := data.Len()
+	deserializedLength, err := length.Deserialize(data)
 	if err != nil {
 		return nil, DeserializationError{
 			Message: "error deserializing length", Serdes: varLen, Remaning: data.Len(), Cause: err,
-		}
+		}.within("", 0)
 	}
 
 	lengthIn, err := varLen.valueAsInt(deserializedLength, data)
@@ -59,7 +184,7 @@ func (varLen VarLength) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 		return nil, err
 	}
 
-	if _, ok := varLen.Data.(Bcd); ok {
+	if bcdData {
 		numBytes := lengthIn / 2
 		if lengthIn > 0 && lengthIn%2 != 0 {
 			numBytes++
@@ -70,24 +195,18 @@ func (varLen VarLength) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	if data.Len() < lengthIn {
 		return nil, DeserializationError{
 			Message: "does not has bytes enough in data buffer", Serdes: varLen, Remaning: data.Len(), Cause: err,
-		}
+			Offset: size - data.Len(),
+		}.shortBuffer(lengthIn, data.Len())
 	}
 
 	if lengthIn == 0 {
 		return bytes.NewBuffer([]byte{}), nil
 	}
 
-	serializedData := bytes.NewBuffer(data.Next(lengthIn))
-	deserializedData, err := varLen.Data.Deserialize(serializedData)
-	if err != nil {
-		return nil, DeserializationError{
-			Message: "deserializer failed", Serdes: varLen, Remaning: data.Len(), Cause: err,
-		}
-	}
-
-	return deserializedData, nil
+	return bytes.NewBuffer(data.Next(lengthIn)), nil
 }
 
+// valueAsInt returns the decoded length, the length starts at the first byte of the VarLength.
 func (varLen VarLength) valueAsInt(deserializedLength serdes.Value, data *bytes.Buffer) (int, error) {
 	lengthStr, ok := deserializedLength.(string)
 	if !ok {
@@ -97,11 +216,27 @@ func (varLen VarLength) valueAsInt(deserializedLength serdes.Value, data *bytes.
 	}
 
 	lengthIn, err := strconv.Atoi(lengthStr)
-	if !ok {
+	if err != nil {
+		return 0, DeserializationError{
+			Message: "length deserializer returned a non numeric string", Serdes: varLen, Remaning: data.Len(),
+			Cause: err, Err: ErrInvalidCharacter,
+		}
+	}
+
+	if lengthIn < 0 {
 		return 0, DeserializationError{
-			Message: "length deserializer returned a non numeric string", Serdes: varLen, Remaning: data.Len(), Cause: err,
+			Message: "length deserializer returned a negative length", Serdes: varLen, Remaning: data.Len(),
+			Err: ErrInvalidCharacter,
 		}
 	}
 
 	return lengthIn, nil
 }
+
+func (varLen VarLength) Children() []Child {
+	return []Child{{Kind: LengthChild, SerDes: varLen.Length}, {Kind: DataChild, SerDes: varLen.Data}}
+}
+
+func (varLen VarLength) FixedSize() (int, bool) {
+	return 0, false
+}
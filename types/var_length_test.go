@@ -177,3 +177,37 @@ func Test_VarLen_Deserialize_Errors(t *testing.T) {
 	_, err = definitions.Deserialize(dataInDataError)
 	assert.Error(t, err)
 }
+
+func Test_VarLen_Deserialize_Invalid_Length(t *testing.T) {
+	list := types.List{Items: []types.Field{
+		{Name: "mti", SerDes: types.Ascii{NumDigits: 4}},
+		{Name: "pan", SerDes: types.VarLength{Length: types.Ascii{NumDigits: 2}, Data: types.Ascii{}}},
+	}}
+
+	for _, length := range []string{"AB", "-1"} {
+		_, err := list.Deserialize(bytes.NewBufferString("0200" + length + "4761"))
+		assert.ErrorIs(t, err, types.ErrInvalidCharacter, length)
+
+		var desErr types.DeserializationError
+		assert.ErrorAs(t, err, &desErr, length)
+		assert.Equal(t, "pan", desErr.Path, length)
+		assert.Equal(t, 4, desErr.Offset, length)
+	}
+}
+
+func Test_VarLen_AppendEncoded(t *testing.T) {
+	varLen := types.VarLength{Length: types.Byte{}, Data: types.Bcd{}}
+
+	data, err := varLen.AppendEncoded([]byte("prefix"), []byte{0x47, 0x61})
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte("prefix"), 0x04, 0x47, 0x61), data)
+
+	expected, err := varLen.Serialize("4761")
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Bytes(), data[len("prefix"):])
+
+	varLen = types.VarLength{Length: types.Ascii{NumDigits: 1}, Data: types.Ascii{}}
+	data, err = varLen.AppendEncoded([]byte("prefix"), []byte("0123456789"))
+	assert.Error(t, err)
+	assert.Equal(t, []byte("prefix"), data)
+}
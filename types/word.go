@@ -10,6 +10,7 @@ import (
 
 type Word struct {
 	Desc
+	Mask
 	Order binary.ByteOrder
 }
 
@@ -18,35 +19,55 @@ func (w Word) Name() string {
 }
 
 func (w Word) Serialize(value serdes.Value) (*bytes.Buffer, error) {
+	return serialize(w, value)
+}
+
+func (w Word) AppendSerialize(dst []byte, value serdes.Value) ([]byte, error) {
 	valueWord, err := w.valueAsInt(value)
 	if err != nil {
-		return nil, err
+		return dst, err
 	}
 
-	data := new(bytes.Buffer)
-	if err := binary.Write(data, w.Order, uint16(valueWord)); err != nil {
-		return nil, SerializerError{
-			Message: "error encoding value", Serdes: w, Value: value, Cause: err,
+	if w.Order == nil {
+		return dst, SerializerError{
+			Message: "error encoding value, byte order not defined", Serdes: w, Value: w.MaskValue(value),
 		}
 	}
 
-	return data, nil
+	start := len(dst)
+	dst = grow(dst, 2)
+	w.Order.PutUint16(dst[start:], uint16(valueWord))
+	return dst, nil
+}
+
+func (w Word) Size(value serdes.Value) (int, error) {
+	if _, err := w.valueAsInt(value); err != nil {
+		return 0, err
+	}
+
+	if w.Order == nil {
+		return 0, SerializerError{
+			Message: "error encoding value, byte order not defined", Serdes: w, Value: w.MaskValue(value),
+		}
+	}
+	return 2, nil
 }
 
 func (w Word) Deserialize(data *bytes.Buffer) (serdes.Value, error) {
 	if data.Len() < 2 {
 		return nil, DeserializationError{
 			Message: "does not has data enough", Serdes: w, Remaning: data.Len(),
-		}
+		}.shortBuffer(2, data.Len())
 	}
 
-	var valueWord uint16
-	if err := binary.Read(data, w.Order, &valueWord); err != nil {
+	if w.Order == nil {
 		return nil, DeserializationError{
-			Message: "error decoding data", Serdes: w, Remaning: data.Len(), Cause: err,
+			Message: "error decoding data, byte order not defined", Serdes: w, Remaning: data.Len(),
 		}
 	}
 
+	valueWord := w.Order.Uint16(data.Next(2))
+
 	value := strconv.FormatUint(uint64(valueWord), 10)
 	return value, nil
 }
@@ -55,15 +76,23 @@ func (w Word) valueAsInt(value serdes.Value) (int, error) {
 	valueStr, ok := value.(string)
 	if !ok {
 		return 0, SerializerError{
-			Message: "invalid value", Value: value, Serdes: w,
+			Message: "invalid value", Value: w.MaskValue(value), Serdes: w,
 		}
 	}
 
 	valueInt, err := strconv.Atoi(valueStr)
 	if err != nil {
 		return 0, SerializerError{
-			Message: "invalid data", Serdes: w, Value: value, Cause: err,
+			Message: "invalid data", Serdes: w, Value: w.MaskValue(value), Cause: numberCause(err),
 		}
 	}
 	return valueInt, nil
 }
+
+func (w Word) Children() []Child {
+	return nil
+}
+
+func (w Word) FixedSize() (int, bool) {
+	return 2, true
+}